@@ -24,6 +24,7 @@ func newInventoryCmd() *cobra.Command {
 		newInventoryInitCmd(),
 		newInventoryPluginCmd(),
 		newInventoryPluginGroupCmd(),
+		newInventoryCLIVersionCmd(),
 	)
 
 	return inventoryCmd
@@ -0,0 +1,64 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/imgpkg"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// InventoryCLIVersionUpdateOptions defines options for updating the recommended CLI version of the inventory database
+type InventoryCLIVersionUpdateOptions struct {
+	Repository        string
+	InventoryImageTag string
+	Version           string
+
+	ImgpkgOptions imgpkg.ImgpkgWrapper
+}
+
+// SetCLIRecommendedVersion sets the version of the Tanzu CLI recommended by the inventory
+// database by downloading the database from the repository, updating it locally and
+// publishing the inventory database as OCI image on the remote repository
+func (icvo *InventoryCLIVersionUpdateOptions) SetCLIRecommendedVersion() error {
+	if !semver.IsValid(icvo.Version) {
+		return errors.Errorf("invalid CLI version %q, the version must be a semantic version starting with 'v', e.g. v1.0.0", icvo.Version)
+	}
+
+	// create plugin inventory database image path
+	pluginInventoryDBImage := fmt.Sprintf("%s/%s:%s", icvo.Repository, helpers.PluginInventoryDBImageName, icvo.InventoryImageTag)
+
+	tempDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temporary directory")
+	}
+
+	log.Infof("pulling plugin inventory database from: %q", pluginInventoryDBImage)
+	dbFile, err := inventoryDBDownload(icvo.ImgpkgOptions, pluginInventoryDBImage, tempDir)
+	if err != nil {
+		return errors.Wrapf(err, "error while downloading inventory database from the repository as image: %q", pluginInventoryDBImage)
+	}
+
+	log.Infof("updating plugin inventory database with the recommended CLI version %q", icvo.Version)
+	db := plugininventory.NewSQLiteInventory(dbFile, "")
+	if err := db.SetCLIRecommendedVersion(icvo.Version); err != nil {
+		return errors.Wrapf(err, "error while setting the recommended CLI version %q", icvo.Version)
+	}
+
+	// Publish the database to the remote repository
+	log.Info("publishing plugin inventory database")
+	err = inventoryDBUpload(icvo.ImgpkgOptions, pluginInventoryDBImage, dbFile)
+	if err != nil {
+		return errors.Wrapf(err, "error while publishing inventory database to the repository as image: %q", pluginInventoryDBImage)
+	}
+	log.Infof("successfully published plugin inventory database at: %q", pluginInventoryDBImage)
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"errors"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/fakes"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+var _ = Describe("Unit tests for inventory cli-version set", func() {
+	var referencedDBFile string
+	var icvo InventoryCLIVersionUpdateOptions
+	fakeImgpkgWrapper := &fakes.ImgpkgWrapper{}
+
+	// pullDBImageStub create new empty database with the table schemas created
+	pullDBImageStub := func(image, path string) error {
+		dbFile := filepath.Join(path, plugininventory.SQliteDBFileName)
+		db := plugininventory.NewSQLiteInventory(dbFile, "")
+		err := db.CreateSchema()
+		Expect(err).ToNot(HaveOccurred())
+		referencedDBFile = dbFile
+		return nil
+	}
+
+	var _ = Context("tests for the inventory cli-version set function", func() {
+
+		BeforeEach(func() {
+			icvo = InventoryCLIVersionUpdateOptions{
+				Repository:        "test-repo.com",
+				InventoryImageTag: "latest",
+				Version:           "v1.1.0",
+				ImgpkgOptions:     fakeImgpkgWrapper,
+			}
+		})
+
+		var _ = It("when the version is not a semantic version", func() {
+			icvo.Version = "1.1"
+			err := icvo.SetCLIRecommendedVersion()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid CLI version"))
+		})
+
+		var _ = It("when plugin inventory database cannot be pulled from the repository", func() {
+			fakeImgpkgWrapper.PullImageReturns(errors.New("unable to pull inventory database"))
+
+			err := icvo.SetCLIRecommendedVersion()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error while pulling database from the image"))
+			Expect(err.Error()).To(ContainSubstring("unable to pull inventory database"))
+		})
+
+		var _ = It("when everything works as expected, the recommended CLI version should be set", func() {
+			fakeImgpkgWrapper.PushImageReturns(nil)
+			fakeImgpkgWrapper.PullImageCalls(pullDBImageStub)
+
+			err := icvo.SetCLIRecommendedVersion()
+			Expect(err).NotTo(HaveOccurred())
+
+			// verify that the local db file was updated correctly before publishing the database to remote repository
+			db := plugininventory.NewSQLiteInventory(referencedDBFile, "")
+			version, err := db.GetCLIRecommendedVersion()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal("v1.1.0"))
+		})
+
+		var _ = It("when inventory database cannot be published from the repository", func() {
+			fakeImgpkgWrapper.PushImageReturns(errors.New("unable to publish image"))
+			fakeImgpkgWrapper.PullImageCalls(pullDBImageStub)
+
+			err := icvo.SetCLIRecommendedVersion()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error while publishing inventory database to the repository as image"))
+			Expect(err.Error()).To(ContainSubstring("unable to publish image"))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/imgpkg"
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/inventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+// newInventoryCLIVersionCmd creates a new command for the CLI version inventory operations.
+func newInventoryCLIVersionCmd() *cobra.Command {
+	var inventoryCLIVersionCmd = &cobra.Command{
+		Use:   "cli-version",
+		Short: "CLI Version Inventory Operations",
+	}
+
+	inventoryCLIVersionCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	inventoryCLIVersionCmd.AddCommand(
+		newInventoryCLIVersionSetCmd(),
+	)
+
+	return inventoryCLIVersionCmd
+}
+
+type inventoryCLIVersionSetFlags struct {
+	Repository        string
+	InventoryImageTag string
+	Version           string
+}
+
+func newInventoryCLIVersionSetCmd() *cobra.Command {
+	var icvsFlags = &inventoryCLIVersionSetFlags{}
+
+	var cliVersionSetCmd = &cobra.Command{
+		Use:          "set",
+		Short:        "Set the version of the Tanzu CLI recommended by the inventory database available on the remote repository",
+		SilenceUsage: true,
+		Example:      ``,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			icvOptions := inventory.InventoryCLIVersionUpdateOptions{
+				Repository:        icvsFlags.Repository,
+				InventoryImageTag: icvsFlags.InventoryImageTag,
+				Version:           icvsFlags.Version,
+				ImgpkgOptions:     imgpkg.NewImgpkgCLIWrapper(),
+			}
+			return icvOptions.SetCLIRecommendedVersion()
+		},
+	}
+
+	cliVersionSetCmd.Flags().StringVarP(&icvsFlags.Repository, "repository", "", "", "repository to publish plugin inventory image")
+	cliVersionSetCmd.Flags().StringVarP(&icvsFlags.InventoryImageTag, "plugin-inventory-image-tag", "", "latest", "tag to which plugin inventory image needs to be published")
+	cliVersionSetCmd.Flags().StringVarP(&icvsFlags.Version, "version", "", "", "recommended version of the Tanzu CLI, e.g. v1.1.0")
+
+	_ = cliVersionSetCmd.MarkFlagRequired("repository")
+	_ = cliVersionSetCmd.MarkFlagRequired("version")
+
+	return cliVersionSetCmd
+}
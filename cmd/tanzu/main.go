@@ -7,17 +7,29 @@ import (
 	"os"
 	"os/exec"
 
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/command"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
 func main() {
 	if err := command.Execute(); err != nil {
-		if errStr, ok := err.(*exec.ExitError); ok {
+		var pluginErr *cli.PluginExitError
+		var exitErr *exec.ExitError
+		switch {
+		case errors.As(err, &pluginErr):
 			// If a plugin exited with an error, we don't want to print its
 			// exit status as a string, but want to use it as our own exit code.
-			os.Exit(errStr.ExitCode())
-		} else {
+			// The reason is only printed if the CLI terminated the plugin.
+			if pluginErr.Reason != "" {
+				log.Error(err, "")
+			}
+			os.Exit(pluginErr.ExitCode())
+		case errors.As(err, &exitErr):
+			os.Exit(exitErr.ExitCode())
+		default:
 			// We got an error other than a plugin exiting with an error, let's
 			// print the error message.
 			log.Fatal(err, "")
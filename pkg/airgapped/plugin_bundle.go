@@ -0,0 +1,170 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package airgapped implements the creation and the upload of plugin bundles
+// which allow to use the Tanzu CLI plugins in internet-restricted environments.
+package airgapped
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// PluginBundleManifestFileName is the name of the manifest file
+	// stored at the root of a plugin bundle
+	PluginBundleManifestFileName = "plugin_bundle_manifest.yaml"
+
+	// pluginInventoryDirName is the directory of the bundle containing
+	// the plugin inventory database
+	pluginInventoryDirName = "plugin-inventory"
+
+	// pluginImagesDirName is the directory of the bundle containing
+	// the files of every plugin image
+	pluginImagesDirName = "plugin-images"
+)
+
+// PluginBundleManifest describes the content of a plugin bundle
+type PluginBundleManifest struct {
+	// RelativeInventoryImagePathWithTag is the path of the inventory image
+	// relative to the repository the bundle gets uploaded to
+	RelativeInventoryImagePathWithTag string `yaml:"relativeInventoryImagePathWithTag"`
+	// InventoryImageDir is the directory of the bundle containing the
+	// files of the inventory image
+	InventoryImageDir string `yaml:"inventoryImageDir"`
+	// Images lists the plugin images included in the bundle
+	Images []*ImageInfo `yaml:"images"`
+}
+
+// ImageInfo describes a single plugin image included in a plugin bundle
+type ImageInfo struct {
+	// RelativeImagePathWithTag is the path of the image relative to
+	// the location of the inventory image
+	RelativeImagePathWithTag string `yaml:"relativeImagePathWithTag"`
+	// FilePath is the directory of the bundle containing the files of the image
+	FilePath string `yaml:"filePath"`
+}
+
+func readPluginBundleManifest(bundleDir string) (*PluginBundleManifest, error) {
+	b, err := os.ReadFile(filepath.Join(bundleDir, PluginBundleManifestFileName))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the plugin bundle manifest")
+	}
+	manifest := &PluginBundleManifest{}
+	if err := yaml.Unmarshal(b, manifest); err != nil {
+		return nil, errors.Wrap(err, "unable to parse the plugin bundle manifest")
+	}
+	return manifest, nil
+}
+
+func writePluginBundleManifest(bundleDir string, manifest *PluginBundleManifest) error {
+	b, err := yaml.Marshal(manifest)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the plugin bundle manifest")
+	}
+	return os.WriteFile(filepath.Join(bundleDir, PluginBundleManifestFileName), b, 0o644)
+}
+
+// createTarGz archives the content of srcDir into the gzipped tar file dstFile
+func createTarGz(srcDir, dstFile string) error {
+	if err := os.MkdirAll(filepath.Dir(dstFile), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(dstFile)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create file '%s'", dstFile)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	defer gw.Close()
+	tw := tar.NewWriter(gw)
+	defer tw.Close()
+
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil || relPath == "." {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(relPath)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tw, file)
+		return err
+	})
+}
+
+// extractTarGz extracts the gzipped tar file srcFile into dstDir
+func extractTarGz(srcFile, dstDir string) error {
+	f, err := os.Open(srcFile)
+	if err != nil {
+		return errors.Wrapf(err, "unable to open file '%s'", srcFile)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.Wrapf(err, "'%s' is not a valid plugin bundle", srcFile)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "unable to read the plugin bundle '%s'", srcFile)
+		}
+
+		target := filepath.Join(dstDir, filepath.FromSlash(hdr.Name)) //nolint:gosec
+		// Protect against archive entries escaping the destination directory
+		if !strings.HasPrefix(target, filepath.Clean(dstDir)+string(os.PathSeparator)) {
+			return errors.Errorf("invalid file path '%s' in the plugin bundle", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR|os.O_TRUNC, os.FileMode(hdr.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil { //nolint:gosec
+				out.Close()
+				return err
+			}
+			out.Close()
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package airgapped
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// DownloadPluginBundleOptions defines the options for downloading a plugin bundle
+type DownloadPluginBundleOptions struct {
+	// PluginInventoryImage is the inventory image of the source repository
+	PluginInventoryImage string
+	// ToTar is the path of the tar file to create
+	ToTar string
+	// Groups restricts the bundle to the plugins of the specified
+	// plugin groups ("vendor-publisher/name")
+	Groups []string
+	// Plugins restricts the bundle to all versions of the specified plugins
+	Plugins []string
+
+	ImageProcessor carvelhelpers.ImageOperationsImpl
+}
+
+// DownloadPluginBundle downloads the plugin inventory image as well as the
+// images of the selected plugins and saves them in a single tar archive.
+// If no group or plugin is specified, the entire repository is included.
+func (o *DownloadPluginBundleOptions) DownloadPluginBundle() error {
+	tempDir, err := os.MkdirTemp("", "plugin_bundle")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Download the plugin inventory of the source repository
+	log.Infof("downloading plugin inventory image %q", o.PluginInventoryImage)
+	sourceInventoryDir := filepath.Join(tempDir, "source-inventory")
+	err = o.ImageProcessor.DownloadImageAndSaveFilesToDir(o.PluginInventoryImage, sourceInventoryDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to download the plugin inventory image %q", o.PluginInventoryImage)
+	}
+	imagePrefix := path.Dir(o.PluginInventoryImage)
+	sourceInventory := plugininventory.NewSQLiteInventory(filepath.Join(sourceInventoryDir, plugininventory.SQliteDBFileName), imagePrefix)
+
+	plugins, groups, err := o.selectPluginsAndGroups(sourceInventory)
+	if err != nil {
+		return err
+	}
+
+	// Create the inventory database which will be part of the bundle
+	bundleDir := filepath.Join(tempDir, "bundle")
+	if err := os.MkdirAll(filepath.Join(bundleDir, pluginInventoryDirName), 0o755); err != nil {
+		return err
+	}
+	bundleInventory := plugininventory.NewSQLiteInventory(filepath.Join(bundleDir, pluginInventoryDirName, plugininventory.SQliteDBFileName), "")
+	if err := bundleInventory.CreateSchema(); err != nil {
+		return err
+	}
+
+	manifest := &PluginBundleManifest{
+		RelativeInventoryImagePathWithTag: path.Base(o.PluginInventoryImage),
+		InventoryImageDir:                 pluginInventoryDirName,
+	}
+	for _, p := range plugins {
+		for _, artifacts := range p.Artifacts {
+			for i := range artifacts {
+				// The inventory stores image paths relative to the inventory image.
+				// Keeping them relative allows the bundle to be uploaded to any repository.
+				if !strings.HasPrefix(artifacts[i].Image, imagePrefix+"/") {
+					return errors.Errorf("image %q of plugin %q is not located in the repository of the inventory %q", artifacts[i].Image, p.Name, o.PluginInventoryImage)
+				}
+				relativeImage := strings.TrimPrefix(artifacts[i].Image, imagePrefix+"/")
+				imageDir := path.Join(pluginImagesDirName, strconv.Itoa(len(manifest.Images)))
+
+				log.Infof("downloading image %q", artifacts[i].Image)
+				err := o.ImageProcessor.DownloadImageAndSaveFilesToDir(artifacts[i].Image, filepath.Join(bundleDir, filepath.FromSlash(imageDir)))
+				if err != nil {
+					return errors.Wrapf(err, "failed to download image %q", artifacts[i].Image)
+				}
+				manifest.Images = append(manifest.Images, &ImageInfo{
+					RelativeImagePathWithTag: relativeImage,
+					FilePath:                 imageDir,
+				})
+				artifacts[i].Image = relativeImage
+			}
+		}
+		if err := bundleInventory.InsertPlugin(p); err != nil {
+			return err
+		}
+	}
+	for _, g := range groups {
+		if err := bundleInventory.InsertPluginGroup(g, false); err != nil {
+			return err
+		}
+	}
+	// The CLI version recommended by the source repository is kept in the bundle
+	cliVersion, err := sourceInventory.GetCLIRecommendedVersion()
+	if err != nil {
+		return err
+	}
+	if cliVersion != "" {
+		if err := bundleInventory.SetCLIRecommendedVersion(cliVersion); err != nil {
+			return err
+		}
+	}
+
+	if err := writePluginBundleManifest(bundleDir, manifest); err != nil {
+		return err
+	}
+
+	log.Infof("saving plugin bundle at %q", o.ToTar)
+	return createTarGz(bundleDir, o.ToTar)
+}
+
+// selectPluginsAndGroups returns the plugins and groups of the inventory that
+// must be included in the bundle based on the requested groups and plugins
+func (o *DownloadPluginBundleOptions) selectPluginsAndGroups(inventory plugininventory.PluginInventory) ([]*plugininventory.PluginInventoryEntry, []*plugininventory.PluginGroup, error) {
+	allPlugins, err := inventory.GetAllPlugins()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to read the plugins of the inventory")
+	}
+	allGroups, err := inventory.GetAllGroups()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to read the plugin groups of the inventory")
+	}
+
+	// Without any selection, the entire repository is part of the bundle
+	if len(o.Groups) == 0 && len(o.Plugins) == 0 {
+		return allPlugins, allGroups, nil
+	}
+
+	var selectedGroups []*plugininventory.PluginGroup
+	// Versions of each plugin (by name and target) required by the selected groups
+	groupVersions := make(map[string]map[string]bool)
+	for _, groupID := range o.Groups {
+		var found *plugininventory.PluginGroup
+		for _, g := range allGroups {
+			if fmt.Sprintf("%s-%s/%s", g.Vendor, g.Publisher, g.Name) == groupID {
+				found = g
+				break
+			}
+		}
+		if found == nil {
+			return nil, nil, errors.Errorf("plugin group %q not found in the inventory", groupID)
+		}
+		selectedGroups = append(selectedGroups, found)
+		for _, pi := range found.Plugins {
+			key := catalog.PluginNameTarget(pi.Name, pi.Target)
+			if groupVersions[key] == nil {
+				groupVersions[key] = make(map[string]bool)
+			}
+			groupVersions[key][pi.Version] = true
+		}
+	}
+
+	requestedPlugins := make(map[string]bool)
+	for _, name := range o.Plugins {
+		requestedPlugins[name] = true
+	}
+
+	var selectedPlugins []*plugininventory.PluginInventoryEntry
+	foundPlugins := make(map[string]bool)
+	for _, p := range allPlugins {
+		if requestedPlugins[p.Name] {
+			// All versions of explicitly requested plugins are included
+			foundPlugins[p.Name] = true
+			selectedPlugins = append(selectedPlugins, p)
+			continue
+		}
+		versions := groupVersions[catalog.PluginNameTarget(p.Name, p.Target)]
+		if len(versions) == 0 {
+			continue
+		}
+		for v := range p.Artifacts {
+			if !versions[v] {
+				delete(p.Artifacts, v)
+			}
+		}
+		selectedPlugins = append(selectedPlugins, p)
+	}
+
+	for _, name := range o.Plugins {
+		if !foundPlugins[name] {
+			return nil, nil, errors.Errorf("plugin %q not found in the inventory", name)
+		}
+	}
+
+	return selectedPlugins, selectedGroups, nil
+}
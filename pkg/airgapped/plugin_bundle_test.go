@@ -0,0 +1,223 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package airgapped
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const (
+	testSourceRepo     = "example.com/source/plugins"
+	testInventoryImage = testSourceRepo + "/plugin-inventory:latest"
+	testDestRepo       = "internal.example.com/tanzu/plugins"
+)
+
+// fakeImageProcessor serves images from memory and records uploaded images
+type fakeImageProcessor struct {
+	inventoryDB string
+	uploadDir   string
+	uploaded    map[string]string
+}
+
+func (f *fakeImageProcessor) DownloadImageAndSaveFilesToDir(imageWithTag, destinationDir string) error {
+	if imageWithTag == testInventoryImage {
+		if err := os.MkdirAll(destinationDir, 0o755); err != nil {
+			return err
+		}
+		return utils.CopyFile(f.inventoryDB, filepath.Join(destinationDir, plugininventory.SQliteDBFileName))
+	}
+	return utils.SaveFile(filepath.Join(destinationDir, "plugin"), []byte(imageWithTag))
+}
+
+func (f *fakeImageProcessor) UploadImageFromDir(imageWithTag, sourceDir string) error {
+	tmpDir, err := os.MkdirTemp(f.uploadDir, "uploaded_image")
+	if err != nil {
+		return err
+	}
+	// Keep a copy of the content as the bundle directory is removed after the upload
+	files, err := os.ReadDir(sourceDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := utils.CopyFile(filepath.Join(sourceDir, file.Name()), filepath.Join(tmpDir, file.Name())); err != nil {
+			return err
+		}
+	}
+	f.uploaded[imageWithTag] = tmpDir
+	return nil
+}
+
+func createTestInventory(t *testing.T, dir string) string {
+	dbFile := filepath.Join(dir, plugininventory.SQliteDBFileName)
+	inventory := plugininventory.NewSQLiteInventory(dbFile, "")
+	assert.Nil(t, inventory.CreateSchema())
+
+	for _, p := range []struct {
+		name     string
+		target   configtypes.Target
+		versions []string
+	}{
+		{"cluster", configtypes.TargetK8s, []string{"v1.0.0", "v1.1.0"}},
+		{"management-cluster", configtypes.TargetK8s, []string{"v1.0.0"}},
+		{"isolated-cluster", configtypes.TargetGlobal, []string{"v0.1.0"}},
+	} {
+		entry := &plugininventory.PluginInventoryEntry{
+			Name:        p.name,
+			Target:      p.target,
+			Description: "plugin " + p.name,
+			Publisher:   "tkg",
+			Vendor:      "vmware",
+			Artifacts:   distribution.Artifacts{},
+		}
+		for _, v := range p.versions {
+			entry.Artifacts[v] = distribution.ArtifactList{
+				{
+					Image:  "linux/amd64/" + string(p.target) + "/" + p.name + ":" + v,
+					Digest: "0000",
+					OS:     "linux",
+					Arch:   "amd64",
+				},
+			}
+		}
+		assert.Nil(t, inventory.InsertPlugin(entry))
+	}
+
+	group := &plugininventory.PluginGroup{
+		Vendor:    "vmware",
+		Publisher: "tkg",
+		Name:      "default",
+		Plugins: []*plugininventory.PluginGroupPluginEntry{
+			{PluginIdentifier: plugininventory.PluginIdentifier{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.1.0"}, Mandatory: true},
+			{PluginIdentifier: plugininventory.PluginIdentifier{Name: "management-cluster", Target: configtypes.TargetK8s, Version: "v1.0.0"}, Mandatory: true},
+		},
+	}
+	assert.Nil(t, inventory.InsertPluginGroup(group, false))
+	assert.Nil(t, inventory.SetCLIRecommendedVersion("v1.1.0"))
+
+	return dbFile
+}
+
+func downloadAndUploadBundle(t *testing.T, groups, plugins []string) (*fakeImageProcessor, []string) {
+	tmpDir := t.TempDir()
+
+	fake := &fakeImageProcessor{
+		inventoryDB: createTestInventory(t, tmpDir),
+		uploadDir:   t.TempDir(),
+		uploaded:    make(map[string]string),
+	}
+	tarFile := filepath.Join(tmpDir, "bundle", "plugin_bundle.tar.gz")
+
+	dOptions := DownloadPluginBundleOptions{
+		PluginInventoryImage: testInventoryImage,
+		ToTar:                tarFile,
+		Groups:               groups,
+		Plugins:              plugins,
+		ImageProcessor:       fake,
+	}
+	assert.Nil(t, dOptions.DownloadPluginBundle())
+
+	uOptions := UploadPluginBundleOptions{
+		Tar:             tarFile,
+		DestinationRepo: testDestRepo,
+		ImageProcessor:  fake,
+	}
+	inventoryImage, err := uOptions.UploadPluginBundle()
+	assert.Nil(t, err)
+	assert.Equal(t, testDestRepo+"/plugin-inventory:latest", inventoryImage)
+
+	var uploadedImages []string
+	for image := range fake.uploaded {
+		uploadedImages = append(uploadedImages, image)
+	}
+	sort.Strings(uploadedImages)
+	return fake, uploadedImages
+}
+
+func TestPluginBundleCompleteRepository(t *testing.T) {
+	fake, uploadedImages := downloadAndUploadBundle(t, nil, nil)
+
+	assert.Equal(t, []string{
+		testDestRepo + "/linux/amd64/global/isolated-cluster:v0.1.0",
+		testDestRepo + "/linux/amd64/kubernetes/cluster:v1.0.0",
+		testDestRepo + "/linux/amd64/kubernetes/cluster:v1.1.0",
+		testDestRepo + "/linux/amd64/kubernetes/management-cluster:v1.0.0",
+		testDestRepo + "/plugin-inventory:latest",
+	}, uploadedImages)
+
+	// The uploaded plugin images contain the original files
+	b, err := os.ReadFile(filepath.Join(fake.uploaded[testDestRepo+"/linux/amd64/kubernetes/cluster:v1.0.0"], "plugin"))
+	assert.Nil(t, err)
+	assert.Equal(t, testSourceRepo+"/linux/amd64/kubernetes/cluster:v1.0.0", string(b))
+
+	// The uploaded inventory must resolve the plugins to the destination repository
+	inventory := plugininventory.NewSQLiteInventory(filepath.Join(fake.uploaded[testDestRepo+"/plugin-inventory:latest"], plugininventory.SQliteDBFileName), testDestRepo)
+	plugins, err := inventory.GetAllPlugins()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(plugins))
+	for _, p := range plugins {
+		for _, artifacts := range p.Artifacts {
+			for _, a := range artifacts {
+				assert.True(t, strings.HasPrefix(a.Image, testDestRepo+"/linux/amd64/"), a.Image)
+			}
+		}
+	}
+	groups, err := inventory.GetAllGroups()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(groups))
+	cliVersion, err := inventory.GetCLIRecommendedVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, "v1.1.0", cliVersion)
+}
+
+func TestPluginBundleSelectedGroupsAndPlugins(t *testing.T) {
+	fake, uploadedImages := downloadAndUploadBundle(t, []string{"vmware-tkg/default"}, []string{"isolated-cluster"})
+
+	// Only the version of the cluster plugin referenced by the group is included
+	assert.Equal(t, []string{
+		testDestRepo + "/linux/amd64/global/isolated-cluster:v0.1.0",
+		testDestRepo + "/linux/amd64/kubernetes/cluster:v1.1.0",
+		testDestRepo + "/linux/amd64/kubernetes/management-cluster:v1.0.0",
+		testDestRepo + "/plugin-inventory:latest",
+	}, uploadedImages)
+
+	inventory := plugininventory.NewSQLiteInventory(filepath.Join(fake.uploaded[testDestRepo+"/plugin-inventory:latest"], plugininventory.SQliteDBFileName), testDestRepo)
+	groups, err := inventory.GetAllGroups()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(groups))
+	assert.Equal(t, "default", groups[0].Name)
+}
+
+func TestPluginBundleUnknownGroupOrPlugin(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	fake := &fakeImageProcessor{inventoryDB: createTestInventory(t, tmpDir)}
+
+	options := DownloadPluginBundleOptions{
+		PluginInventoryImage: testInventoryImage,
+		ToTar:                filepath.Join(tmpDir, "plugin_bundle.tar.gz"),
+		Groups:               []string{"vmware-tkg/unknown"},
+		ImageProcessor:       fake,
+	}
+	err := options.DownloadPluginBundle()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `plugin group "vmware-tkg/unknown" not found`)
+
+	options.Groups = nil
+	options.Plugins = []string{"unknown"}
+	err = options.DownloadPluginBundle()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `plugin "unknown" not found`)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package airgapped
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+)
+
+// UploadPluginBundleOptions defines the options for uploading a plugin bundle
+type UploadPluginBundleOptions struct {
+	// Tar is the path of the plugin bundle created with DownloadPluginBundle
+	Tar string
+	// DestinationRepo is the repository the plugins get uploaded to
+	DestinationRepo string
+
+	ImageProcessor carvelhelpers.ImageOperationsImpl
+}
+
+// UploadPluginBundle uploads the plugin images and the plugin inventory
+// contained in a plugin bundle to the destination repository.
+// It returns the inventory image which can be used as a discovery source.
+func (o *UploadPluginBundleOptions) UploadPluginBundle() (string, error) {
+	tempDir, err := os.MkdirTemp("", "plugin_bundle")
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	log.Infof("extracting plugin bundle %q", o.Tar)
+	if err := extractTarGz(o.Tar, tempDir); err != nil {
+		return "", err
+	}
+	manifest, err := readPluginBundleManifest(tempDir)
+	if err != nil {
+		return "", err
+	}
+
+	repo := strings.TrimSuffix(o.DestinationRepo, "/")
+	for _, image := range manifest.Images {
+		destImage := repo + "/" + image.RelativeImagePathWithTag
+		log.Infof("uploading image %q", destImage)
+		err := o.ImageProcessor.UploadImageFromDir(destImage, filepath.Join(tempDir, filepath.FromSlash(image.FilePath)))
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to upload image %q", destImage)
+		}
+	}
+
+	// The inventory references the plugin images relative to its own location,
+	// so pushing it to the root of the destination repository makes every
+	// plugin URI resolve to the images uploaded above.
+	inventoryImage := repo + "/" + manifest.RelativeInventoryImagePathWithTag
+	log.Infof("uploading plugin inventory image %q", inventoryImage)
+	err = o.ImageProcessor.UploadImageFromDir(inventoryImage, filepath.Join(tempDir, filepath.FromSlash(manifest.InventoryImageDir)))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to upload the plugin inventory image %q", inventoryImage)
+	}
+
+	return inventoryImage, nil
+}
@@ -38,48 +38,90 @@ func NewHTTPArtifact(url string) Artifact {
 }
 
 // Fetch an artifact.
+// Failed downloads are retried and, if the server supports it, resumed
+// from where the previous attempt stopped using HTTP Range requests.
 func (g *HTTPArtifact) Fetch() ([]byte, error) {
+	out := []byte{}
+	err := withRetries(g.URL, func(attempt int) error {
+		var err error
+		out, err = g.fetchFrom(out)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// fetchFrom downloads the artifact and appends it to the already downloaded
+// data. It always returns the data downloaded so far, even on error, to allow
+// the next attempt to resume the download.
+func (g *HTTPArtifact) fetchFrom(downloaded []byte) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", g.URL, http.NoBody)
 	if err != nil {
-		return nil, err
+		return downloaded, &permanentError{err: err}
 	}
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	req.Header.Set("Accept", "application/json; charset=utf-8")
+	if len(downloaded) > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", len(downloaded)))
+	}
 
 	res, err := g.HTTPClient.Do(req)
 
 	if err != nil {
-		return nil, err
+		return downloaded, err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(ErrorMsgHTTPArtifactDownload, req.URL, res.StatusCode)
+	out := downloaded
+	switch {
+	case res.StatusCode == http.StatusPartialContent && len(downloaded) > 0:
+		// The server resumes the download where the previous attempt stopped
+	case res.StatusCode == http.StatusOK:
+		// The server sends the complete artifact
+		out = []byte{}
+	case res.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The partially downloaded data cannot be used, restart from scratch
+		return []byte{}, fmt.Errorf(ErrorMsgHTTPArtifactDownload, req.URL, res.StatusCode)
+	case isRetryableStatusCode(res.StatusCode):
+		return downloaded, fmt.Errorf(ErrorMsgHTTPArtifactDownload, req.URL, res.StatusCode)
+	default:
+		return downloaded, &permanentError{err: fmt.Errorf(ErrorMsgHTTPArtifactDownload, req.URL, res.StatusCode)}
 	}
 
 	buf := make([]byte, bufferSize)
-	out := []byte{}
 
 	for {
 		// read a chunk of response body
 		n, err := res.Body.Read(buf)
-		if err != nil && err != io.EOF {
-			return nil, err
+		// append chunk by chunk
+		out = append(out, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return out, errors.Wrapf(err, "download interrupted after %d bytes", len(out))
 		}
 		if n == 0 {
 			break
 		}
-
-		// append chunk by chunk
-		out = append(out, buf[:n]...)
 	}
 
 	return out, nil
 }
 
+// isRetryableStatusCode returns true if a new attempt could succeed
+// after receiving the specified status code
+func isRetryableStatusCode(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests
+}
+
 // FetchTest returns test artifact
 func (g *HTTPArtifact) FetchTest() ([]byte, error) {
 	return nil, errors.New("fetching test plugin from HTTP source is not yet supported")
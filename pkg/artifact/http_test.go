@@ -8,10 +8,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
 )
 
@@ -24,6 +27,8 @@ var (
 const dummyURL = "http://dummy.com"
 
 func initialize(url string) {
+	// Do not wait between download attempts
+	retrySleep = func(time.Duration) {}
 	fakeHTTPClient = &fakes.FakeHTTPClient{}
 	httpArtifact = &HTTPArtifact{
 		URL:        url,
@@ -76,3 +81,99 @@ func TestHttpArtifact_errorResponse(t *testing.T) {
 	_, err := httpArtifact.Fetch()
 	assert.Contains(err.Error(), errorMsg)
 }
+
+// interruptedReader returns its data followed by an error
+type interruptedReader struct {
+	data []byte
+	done bool
+}
+
+func (r *interruptedReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, fmt.Errorf("connection reset by peer")
+	}
+	r.done = true
+	return copy(p, r.data), nil
+}
+
+func TestHttpArtifact_retryOnError(t *testing.T) {
+	assert := assert.New(t)
+	initialize(dummyURL)
+
+	fakeHTTPClient.DoReturnsOnCall(0, nil, fmt.Errorf("connection refused"))
+	fakeHTTPClient.DoReturnsOnCall(1, &http.Response{StatusCode: 503, Body: io.NopCloser(bytes.NewReader(nil))}, nil)
+	fakeHTTPClient.DoReturnsOnCall(2, &http.Response{StatusCode: 200, Body: responseBody}, nil)
+
+	resp, err := httpArtifact.Fetch()
+	assert.Nil(err)
+	assert.Equal(`{"name":"dummy name"}`, string(resp))
+	assert.Equal(3, fakeHTTPClient.DoCallCount())
+}
+
+func TestHttpArtifact_reportAllAttempts(t *testing.T) {
+	assert := assert.New(t)
+	initialize(dummyURL)
+	os.Setenv(constants.ArtifactDownloadMaxRetries, "1")
+	defer os.Unsetenv(constants.ArtifactDownloadMaxRetries)
+
+	fakeHTTPClient.DoReturnsOnCall(0, nil, fmt.Errorf("connection refused"))
+	fakeHTTPClient.DoReturnsOnCall(1, &http.Response{StatusCode: 502, Body: io.NopCloser(bytes.NewReader(nil))}, nil)
+
+	_, err := httpArtifact.Fetch()
+	assert.NotNil(err)
+	assert.Equal(2, fakeHTTPClient.DoCallCount())
+	assert.Contains(err.Error(), "after 2 attempts")
+	assert.Contains(err.Error(), "attempt 1: connection refused")
+	assert.Contains(err.Error(), "attempt 2: "+fmt.Sprintf(ErrorMsgHTTPArtifactDownload, dummyURL, 502))
+}
+
+func TestHttpArtifact_noRetryOnClientError(t *testing.T) {
+	assert := assert.New(t)
+	initialize(dummyURL)
+
+	fakeHTTPClient.DoReturns(&http.Response{StatusCode: 404, Body: responseBody}, nil)
+
+	_, err := httpArtifact.Fetch()
+	assert.NotNil(err)
+	assert.Equal(1, fakeHTTPClient.DoCallCount())
+	assert.Equal(fmt.Sprintf(ErrorMsgHTTPArtifactDownload, dummyURL, 404), err.Error())
+}
+
+func TestHttpArtifact_resumeDownload(t *testing.T) {
+	assert := assert.New(t)
+	initialize(dummyURL)
+
+	fakeHTTPClient.DoReturnsOnCall(0, &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(&interruptedReader{data: []byte("first-")}),
+	}, nil)
+	fakeHTTPClient.DoReturnsOnCall(1, &http.Response{
+		StatusCode: 206,
+		Body:       io.NopCloser(bytes.NewReader([]byte("second"))),
+	}, nil)
+
+	resp, err := httpArtifact.Fetch()
+	assert.Nil(err)
+	assert.Equal("first-second", string(resp))
+	assert.Equal(2, fakeHTTPClient.DoCallCount())
+	assert.Equal("", fakeHTTPClient.DoArgsForCall(0).Header.Get("Range"))
+	assert.Equal("bytes=6-", fakeHTTPClient.DoArgsForCall(1).Header.Get("Range"))
+}
+
+func TestHttpArtifact_restartDownloadWhenRangeIsIgnored(t *testing.T) {
+	assert := assert.New(t)
+	initialize(dummyURL)
+
+	fakeHTTPClient.DoReturnsOnCall(0, &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(&interruptedReader{data: []byte("partial")}),
+	}, nil)
+	fakeHTTPClient.DoReturnsOnCall(1, &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewReader([]byte("complete"))),
+	}, nil)
+
+	resp, err := httpArtifact.Fetch()
+	assert.Nil(err)
+	assert.Equal("complete", string(resp))
+}
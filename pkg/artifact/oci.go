@@ -4,12 +4,16 @@
 package artifact
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	"github.com/pkg/errors"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
@@ -21,6 +25,9 @@ type fileMapGetterFn func(string) (map[string][]byte, error)
 type OCIArtifact struct {
 	Image                string
 	getFilesMapFromImage fileMapGetterFn
+	// signatureVerifier, when set, is used to verify the signature
+	// of the image before fetching it
+	signatureVerifier cosignhelper.Cosignhelper
 }
 
 // NewOCIArtifact creates OCI Artifact object
@@ -31,9 +38,33 @@ func NewOCIArtifact(image string) Artifact {
 	}
 }
 
+// NewOCIArtifactWithSignatureVerification creates OCI Artifact object
+// which verifies the signature of the image before fetching it
+func NewOCIArtifactWithSignatureVerification(image string, verifier cosignhelper.Cosignhelper) Artifact {
+	return &OCIArtifact{
+		Image:                image,
+		getFilesMapFromImage: carvelhelpers.GetFilesMapFromImage,
+		signatureVerifier:    verifier,
+	}
+}
+
 // Fetch an artifact.
 func (g *OCIArtifact) Fetch() ([]byte, error) {
-	filesMap, err := g.getFilesMapFromImage(g.Image)
+	if g.signatureVerifier != nil {
+		if err := g.signatureVerifier.Verify(context.Background(), []string{g.Image}); err != nil {
+			return nil, errors.Wrapf(err, "plugin image %q signature verification failed", g.Image)
+		}
+	}
+
+	var filesMap map[string][]byte
+	err := withRetries(g.Image, func(attempt int) error {
+		var err error
+		filesMap, err = g.getFilesMapFromImage(g.Image)
+		if err != nil && isPermanentOCIError(err) {
+			return &permanentError{err: err}
+		}
+		return err
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "unable fetch plugin binary")
 	}
@@ -58,6 +89,20 @@ func (g *OCIArtifact) Fetch() ([]byte, error) {
 	return bytesData, nil
 }
 
+// isPermanentOCIError returns true if a new attempt to fetch the image would
+// fail the same way, e.g., the image reference is invalid, the image does not
+// exist or the access to the image is denied
+func isPermanentOCIError(err error) bool {
+	if name.IsErrBadName(err) {
+		return true
+	}
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) {
+		return !isRetryableStatusCode(transportErr.StatusCode) && !transportErr.Temporary()
+	}
+	return false
+}
+
 // FetchTest returns test artifact
 func (g *OCIArtifact) FetchTest() ([]byte, error) {
 	return nil, errors.New("fetching test plugin from OCI source is not yet supported")
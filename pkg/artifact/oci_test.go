@@ -3,7 +3,17 @@
 package artifact
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
 )
 
 func TestOCIArtifactWhenMultipleFilesFound(t *testing.T) {
@@ -33,3 +43,101 @@ func TestOCIArtifactWhenMultipleFilesFound(t *testing.T) {
 		t.Fatalf("Did not receive the expected error message. Expected '%s', got '%s'", expectedErrorMessage, err.Error())
 	}
 }
+
+func TestOCIArtifactRetry(t *testing.T) {
+	retrySleep = func(time.Duration) {}
+	artifact := NewOCIArtifact("foo")
+	o, _ := artifact.(*OCIArtifact)
+	calls := 0
+	o.getFilesMapFromImage = func(s string) (map[string][]byte, error) {
+		calls++
+		if calls < 3 {
+			return nil, fmt.Errorf("temporary failure %d", calls)
+		}
+		return map[string][]byte{"file1": []byte("binary")}, nil
+	}
+
+	data, err := o.Fetch()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "binary" || calls != 3 {
+		t.Fatalf("Expected the binary after 3 attempts, got '%s' after %d attempts", data, calls)
+	}
+
+	calls = -10
+	_, err = o.Fetch()
+	if err == nil || !strings.Contains(err.Error(), "after 4 attempts") || !strings.Contains(err.Error(), "attempt 4: temporary failure -6") {
+		t.Fatalf("Expected an error reporting every attempt, got '%v'", err)
+	}
+}
+
+func TestOCIArtifactWithSignatureVerification(t *testing.T) {
+	expectedImageName := "foo"
+	verifier := &fakes.Cosignhelperfake{}
+	artifact := NewOCIArtifactWithSignatureVerification(expectedImageName, verifier)
+	o, _ := artifact.(*OCIArtifact)
+	o.getFilesMapFromImage = func(s string) (map[string][]byte, error) {
+		return map[string][]byte{"file1": []byte("binary")}, nil
+	}
+
+	// Signature verification fails
+	verifier.VerifyReturns(fmt.Errorf("fake verification error"))
+	data, err := o.Fetch()
+	if err == nil || !strings.Contains(err.Error(), "fake verification error") {
+		t.Fatalf("Expected a signature verification error, got '%v'", err)
+	}
+	if len(data) != 0 {
+		t.Fatalf("Expected no data when the signature verification fails, got '%+v'", data)
+	}
+
+	// Signature verification succeeds
+	verifier.VerifyReturns(nil)
+	data, err = o.Fetch()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "binary" {
+		t.Fatalf("Expected to receive the binary, got '%s'", data)
+	}
+	_, images := verifier.VerifyArgsForCall(1)
+	if len(images) != 1 || images[0] != expectedImageName {
+		t.Fatalf("Expected the signature of '%s' to be verified, got '%v'", expectedImageName, images)
+	}
+}
+
+func TestOCIArtifactNoRetryOnPermanentError(t *testing.T) {
+	retrySleep = func(time.Duration) {}
+	artifact := NewOCIArtifact("foo")
+	o, _ := artifact.(*OCIArtifact)
+
+	permanentErrors := []error{
+		&name.ErrBadName{},
+		errors.Wrap(&transport.Error{StatusCode: http.StatusNotFound, Errors: []transport.Diagnostic{{Code: transport.ManifestUnknownErrorCode}}}, "Collecting images"),
+		&transport.Error{StatusCode: http.StatusUnauthorized, Errors: []transport.Diagnostic{{Code: transport.UnauthorizedErrorCode}}},
+		&transport.Error{StatusCode: http.StatusForbidden},
+	}
+	for _, permanentErr := range permanentErrors {
+		calls := 0
+		o.getFilesMapFromImage = func(s string) (map[string][]byte, error) {
+			calls++
+			return nil, permanentErr
+		}
+		if _, err := o.Fetch(); err == nil || calls != 1 {
+			t.Fatalf("Expected a single attempt for '%v', got %d attempts", permanentErr, calls)
+		}
+	}
+
+	// Errors of the registry which may not happen again are retried
+	calls := 0
+	o.getFilesMapFromImage = func(s string) (map[string][]byte, error) {
+		calls++
+		if calls < 2 {
+			return nil, &transport.Error{StatusCode: http.StatusServiceUnavailable}
+		}
+		return map[string][]byte{"file1": []byte("binary")}, nil
+	}
+	if _, err := o.Fetch(); err != nil || calls != 2 {
+		t.Fatalf("Expected the binary after 2 attempts, got '%v' after %d attempts", err, calls)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 1 * time.Second
+	maxBackoff            = 30 * time.Second
+)
+
+// retrySleep waits between two attempts. It is a variable to allow
+// tests to avoid waiting.
+var retrySleep = time.Sleep
+
+// permanentError is an error for which a new attempt would not help
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// retryOptions configures how failed downloads are retried
+type retryOptions struct {
+	// maxRetries is the number of attempts made after the first one failed
+	maxRetries int
+	// initialBackoff is the wait time before the first retry.
+	// It is doubled before every subsequent retry.
+	initialBackoff time.Duration
+}
+
+// getRetryOptions returns the retry options configured through the
+// TANZU_CLI_ARTIFACT_DOWNLOAD_MAX_RETRIES and
+// TANZU_CLI_ARTIFACT_DOWNLOAD_RETRY_INITIAL_BACKOFF variables
+func getRetryOptions() retryOptions {
+	opts := retryOptions{
+		maxRetries:     defaultMaxRetries,
+		initialBackoff: defaultInitialBackoff,
+	}
+	if value := os.Getenv(constants.ArtifactDownloadMaxRetries); value != "" {
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 {
+			log.Warningf("invalid value %q for %s, using the default of %d retries", value, constants.ArtifactDownloadMaxRetries, defaultMaxRetries)
+		} else {
+			opts.maxRetries = retries
+		}
+	}
+	if value := os.Getenv(constants.ArtifactDownloadRetryInitialBackoff); value != "" {
+		backoff, err := time.ParseDuration(value)
+		if err != nil || backoff < 0 {
+			log.Warningf("invalid value %q for %s, using the default of %v", value, constants.ArtifactDownloadRetryInitialBackoff, defaultInitialBackoff)
+		} else {
+			opts.initialBackoff = backoff
+		}
+	}
+	return opts
+}
+
+// withRetries calls fn until it succeeds, it returns a permanentError or the
+// maximum number of retries is reached. The wait time between attempts grows
+// exponentially. Every failed attempt is reported along with its cause.
+func withRetries(description string, fn func(attempt int) error) error {
+	opts := getRetryOptions()
+	totalAttempts := opts.maxRetries + 1
+	backoff := opts.initialBackoff
+
+	var attemptErrors []string
+	for attempt := 1; ; attempt++ {
+		err := fn(attempt)
+		if err == nil {
+			return nil
+		}
+		attemptErrors = append(attemptErrors, fmt.Sprintf("attempt %d: %v", attempt, err))
+
+		if _, permanent := err.(*permanentError); permanent || attempt >= totalAttempts {
+			if len(attemptErrors) == 1 {
+				return err
+			}
+			return fmt.Errorf("failed to download %s after %d attempts: [%s]", description, attempt, strings.Join(attemptErrors, "; "))
+		}
+
+		log.Warningf("attempt %d/%d to download %s failed: %v. Retrying in %v", attempt, totalAttempts, description, err, backoff)
+		retrySleep(backoff)
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
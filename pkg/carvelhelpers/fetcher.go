@@ -78,6 +78,22 @@ func GetImageDigest(imageWithTag string) (string, string, error) {
 	return hashAlgorithm, hashHexVal, nil
 }
 
+// UploadImageFromDir pushes the files of the specified directory
+// as a plain OCI image
+func UploadImageFromDir(imageWithTag, sourceDir string) error {
+	reg, err := newRegistry()
+	if err != nil {
+		return errors.Wrapf(err, "unable to initialize registry")
+	}
+
+	err = reg.UploadImage(imageWithTag, sourceDir)
+	if err != nil {
+		return errors.Wrap(err, "error uploading image")
+	}
+
+	return nil
+}
+
 // newRegistry returns a new registry object by also
 // taking into account for any custom registry or proxy
 // environment variable provided by the user
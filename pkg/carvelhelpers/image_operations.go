@@ -0,0 +1,35 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package carvelhelpers
+
+// ImageOperationsImpl defines the image operations needed to move
+// plugin images between registries and the local filesystem
+type ImageOperationsImpl interface {
+	// DownloadImageAndSaveFilesToDir reads a plain OCI image and saves its
+	// files to the specified location.
+	DownloadImageAndSaveFilesToDir(imageWithTag, destinationDir string) error
+	// UploadImageFromDir pushes the files of the specified directory
+	// as a plain OCI image
+	UploadImageFromDir(imageWithTag, sourceDir string) error
+}
+
+type imageOperations struct{}
+
+// NewImageOperationsImpl returns the ImageOperationsImpl backed by
+// the configured image registry
+func NewImageOperationsImpl() ImageOperationsImpl {
+	return &imageOperations{}
+}
+
+// DownloadImageAndSaveFilesToDir reads a plain OCI image and saves its
+// files to the specified location.
+func (i *imageOperations) DownloadImageAndSaveFilesToDir(imageWithTag, destinationDir string) error {
+	return DownloadImageAndSaveFilesToDir(imageWithTag, destinationDir)
+}
+
+// UploadImageFromDir pushes the files of the specified directory
+// as a plain OCI image
+func (i *imageOperations) UploadImageFromDir(imageWithTag, sourceDir string) error {
+	return UploadImageFromDir(imageWithTag, sourceDir)
+}
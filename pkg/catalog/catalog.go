@@ -33,35 +33,66 @@ var (
 type ContextCatalog struct {
 	sharedCatalog *Catalog
 	plugins       PluginAssociation
+	context       string
 }
 
 // NewContextCatalog creates context-aware catalog
 func NewContextCatalog(context string) (*ContextCatalog, error) {
+	c := &ContextCatalog{context: context}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load reads the shared catalog from the local directory and
+// selects the plugins associated with the context of the catalog.
+func (c *ContextCatalog) load() error {
 	sc, err := getCatalogCache()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	var plugins PluginAssociation
-	if context == "" {
+	if c.context == "" {
 		plugins = sc.StandAlonePlugins
 	} else {
 		var ok bool
-		plugins, ok = sc.ServerPlugins[context]
+		plugins, ok = sc.ServerPlugins[c.context]
 		if !ok {
 			plugins = make(PluginAssociation)
-			sc.ServerPlugins[context] = plugins
+			sc.ServerPlugins[c.context] = plugins
 		}
 	}
 
-	return &ContextCatalog{
-		sharedCatalog: sc,
-		plugins:       plugins,
-	}, nil
+	c.sharedCatalog = sc
+	c.plugins = plugins
+	return nil
+}
+
+// update applies the modification to the latest content of the catalog
+// and saves it while holding the catalog lock, so that the modifications
+// made concurrently by other processes are not lost.
+func (c *ContextCatalog) update(modify func()) error {
+	unlock, err := lockCatalog()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := c.load(); err != nil {
+		return err
+	}
+	modify()
+	return saveCatalogCache(c.sharedCatalog)
 }
 
 // Upsert inserts/updates the given plugin.
 func (c *ContextCatalog) Upsert(plugin *cli.PluginInfo) error {
+	return c.update(func() { c.upsert(plugin) })
+}
+
+func (c *ContextCatalog) upsert(plugin *cli.PluginInfo) {
 	pluginNameTarget := PluginNameTarget(plugin.Name, plugin.Target)
 
 	c.plugins[pluginNameTarget] = plugin.InstallationPath
@@ -84,8 +115,6 @@ func (c *ContextCatalog) Upsert(plugin *cli.PluginInfo) error {
 		c.deleteOldTargetEntries(PluginNameTarget(plugin.Name, configtypes.TargetGlobal))
 		c.deleteOldTargetEntries(PluginNameTarget(plugin.Name, configtypes.TargetK8s))
 	}
-
-	return saveCatalogCache(c.sharedCatalog)
 }
 
 func (c *ContextCatalog) deleteOldTargetEntries(key string) {
@@ -127,12 +156,15 @@ func (c *ContextCatalog) List() []cli.PluginInfo {
 // Delete deletes the given plugin from the catalog, but it does not delete
 // the installation.
 func (c *ContextCatalog) Delete(plugin string) error {
-	_, ok := c.plugins[plugin]
-	if ok {
+	return c.update(func() {
 		delete(c.plugins, plugin)
-	}
+	})
+}
 
-	return saveCatalogCache(c.sharedCatalog)
+// GetCatalogCacheDir returns the directory of the plugin catalog, which also
+// holds the other files tracking the state of the installed plugins
+func GetCatalogCacheDir() string {
+	return getCatalogCacheDir()
 }
 
 // getCatalogCacheDir returns the local directory in which tanzu state is stored.
@@ -175,7 +207,7 @@ func getCatalogCache() (catalog *Catalog, err error) {
 	var c Catalog
 	err = yaml.Unmarshal(b, &c)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode catalog file")
+		return nil, &InvalidCatalogError{Reason: fmt.Sprintf("could not decode catalog file: %v", err)}
 	}
 
 	if c.IndexByPath == nil {
@@ -191,6 +223,11 @@ func getCatalogCache() (catalog *Catalog, err error) {
 		c.ServerPlugins = map[string]PluginAssociation{}
 	}
 
+	// The migrated catalog is written to disk on the next update
+	if err := migrateCatalog(&c); err != nil {
+		return nil, err
+	}
+
 	return &c, nil
 }
 
@@ -207,19 +244,51 @@ func saveCatalogCache(catalog *Catalog) error {
 		return errors.Wrap(err, "could not create catalog cache path")
 	}
 
+	catalog.SchemaVersion = CatalogSchemaVersion
 	out, err := yaml.Marshal(catalog)
 	if err != nil {
 		return errors.Wrap(err, "failed to encode catalog cache file")
 	}
 
-	if err = os.WriteFile(catalogCachePath, out, 0644); err != nil {
+	// Write to a temporary file and rename it so that a process
+	// reading the catalog never sees a partially written file
+	if err = writeFileAtomically(catalogCachePath, out, 0644); err != nil {
 		return errors.Wrap(err, "failed to write catalog cache file")
 	}
 	return nil
 }
 
+// writeFileAtomically writes the data to a temporary file of the same
+// directory and renames it to the target file
+func writeFileAtomically(path string, data []byte, perm os.FileMode) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err = tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
 // CleanCatalogCache cleans the catalog cache
 func CleanCatalogCache() error {
+	unlock, err := lockCatalog()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if err := os.Remove(getCatalogCachePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
@@ -249,6 +318,12 @@ func testPath() string {
 // UpdateCatalogCache when updating the core CLI from v0.x.x to v1.x.x. This is
 // needed to group the standalone plugins by context type.
 func UpdateCatalogCache() error {
+	unlock, err := lockCatalog()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	c, err := getCatalogCache()
 	if err != nil {
 		return err
@@ -257,6 +332,125 @@ func UpdateCatalogCache() error {
 	return saveCatalogCache(c)
 }
 
+// RebuildCatalog replaces the catalog with a new catalog indexing the given
+// plugins. The associations link each context with its plugins; the stand-alone
+// plugins are associated with the empty context name.
+func RebuildCatalog(plugins []cli.PluginInfo, associations map[string]PluginAssociation) error {
+	unlock, err := lockCatalog()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	sc, err := newSharedCatalog()
+	if err != nil {
+		return err
+	}
+	for i := range plugins {
+		pluginNameTarget := PluginNameTarget(plugins[i].Name, plugins[i].Target)
+		sc.IndexByPath[plugins[i].InstallationPath] = plugins[i]
+		if !utils.ContainsString(sc.IndexByName[pluginNameTarget], plugins[i].InstallationPath) {
+			sc.IndexByName[pluginNameTarget] = append(sc.IndexByName[pluginNameTarget], plugins[i].InstallationPath)
+		}
+	}
+	for context, association := range associations {
+		if context == "" {
+			sc.StandAlonePlugins = association
+		} else {
+			sc.ServerPlugins[context] = association
+		}
+	}
+	return saveCatalogCache(sc)
+}
+
+// CatalogCacheExists returns true if the catalog file exists
+func CatalogCacheExists() (bool, error) {
+	_, err := os.Stat(getCatalogCachePath())
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// ListPluginsByContext returns the plugins associated with each context of the
+// catalog. The stand-alone plugins are associated with the empty context name.
+func ListPluginsByContext() (map[string][]cli.PluginInfo, error) {
+	c, err := getCatalogCache()
+	if err != nil {
+		return nil, err
+	}
+
+	pluginsByContext := make(map[string][]cli.PluginInfo)
+	addPlugins := func(context string, plugins PluginAssociation) {
+		for _, installationPath := range plugins {
+			if pd, ok := c.IndexByPath[installationPath]; ok {
+				pluginsByContext[context] = append(pluginsByContext[context], pd)
+			}
+		}
+	}
+	addPlugins("", c.StandAlonePlugins)
+	for context, plugins := range c.ServerPlugins {
+		addPlugins(context, plugins)
+	}
+	return pluginsByContext, nil
+}
+
+// ListInstallationPaths returns the installation paths of all the plugin
+// binaries known to the catalog
+func ListInstallationPaths() ([]string, error) {
+	c, err := getCatalogCache()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(c.IndexByPath))
+	for installationPath := range c.IndexByPath {
+		paths = append(paths, installationPath)
+	}
+	return paths, nil
+}
+
+// ListPluginInstallations returns all the installed versions of the plugin,
+// whether they are in use or not. All the targets of the plugin are returned
+// if the target is unknown.
+func ListPluginInstallations(pluginName string, target configtypes.Target) ([]cli.PluginInfo, error) {
+	c, err := getCatalogCache()
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []cli.PluginInfo
+	for _, pd := range c.IndexByPath {
+		if pd.Name == pluginName && (target == configtypes.TargetUnknown || pd.Target == target) {
+			plugins = append(plugins, pd)
+		}
+	}
+	return plugins, nil
+}
+
+// ListPluginAliases returns the aliases of all the plugins known to the catalog,
+// whether they are in use or not, keyed by the name and target of the plugin
+func ListPluginAliases() (map[string][]string, error) {
+	c, err := getCatalogCache()
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make(map[string][]string)
+	for _, pd := range c.IndexByPath {
+		key := PluginNameTarget(pd.Name, pd.Target)
+		for _, alias := range pd.Aliases {
+			if !utils.ContainsString(aliases[key], alias) {
+				aliases[key] = append(aliases[key], alias)
+			}
+		}
+	}
+	return aliases, nil
+}
+
 // PluginNameTarget constructs a string to uniquely refer to a plugin associated
 // with a specific target when target is provided.
 func PluginNameTarget(pluginName string, target configtypes.Target) string {
@@ -36,6 +36,9 @@ func (pa PluginAssociation) Map() map[string]string {
 
 // Catalog is the Schema for the plugin catalog data
 type Catalog struct {
+	// SchemaVersion is the version of the format of the catalog
+	SchemaVersion int `json:"schemaVersion,omitempty" yaml:"schemaVersion,omitempty"`
+
 	// PluginInfos is a list of PluginInfo
 	PluginInfos []*cli.PluginInfo `json:"pluginInfos,omitempty" yaml:"pluginInfos,omitempty"`
 
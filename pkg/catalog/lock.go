@@ -0,0 +1,81 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/fslock"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+const (
+	// catalogLockFileName is the name of the lock file protecting the catalog cache file
+	catalogLockFileName = ".catalog.lock"
+	// pluginRootLockFileName is the name of the lock file protecting the plugin root directory
+	pluginRootLockFileName = ".plugin-root.lock"
+
+	// DefaultLockTimeout is the default time waiting for another process to release a lock
+	DefaultLockTimeout = 2 * time.Minute
+)
+
+// lockCatalog acquires the inter-process lock on the catalog cache file
+// and returns the function releasing it. The catalog lock is only held while
+// the catalog file is read, modified and written back.
+func lockCatalog() (func(), error) {
+	return acquireLock(filepath.Join(getCatalogCacheDir(), catalogLockFileName), "the plugin catalog")
+}
+
+// LockPluginRoot acquires the inter-process lock on the plugin root directory
+// and returns the function releasing it. It must be held while plugin binaries
+// are written to or removed from the plugin root directory, along with the
+// associated catalog updates.
+// The plugin root lock must never be acquired while holding the catalog lock.
+func LockPluginRoot() (func(), error) {
+	return acquireLock(filepath.Join(getCatalogCacheDir(), pluginRootLockFileName), "the plugin directory")
+}
+
+// acquireLock waits for the lock file to be available for at most the
+// configured lock timeout. The lock is automatically released by the
+// operating system if the process holding it terminates.
+func acquireLock(lockPath, description string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, errors.Wrap(err, "could not make tanzu cache directory")
+	}
+
+	timeout := getLockTimeout()
+	lock := fslock.New(lockPath)
+	if err := lock.LockWithTimeout(timeout); err != nil {
+		if errors.Is(err, fslock.ErrTimeout) {
+			return nil, errors.Errorf("timed out after %v waiting for another tanzu process to release the lock on %s (%s). "+
+				"Retry once the other process completes or increase the wait time using the %s variable",
+				timeout, description, lockPath, constants.PluginCatalogLockTimeout)
+		}
+		return nil, errors.Wrapf(err, "unable to lock %s", description)
+	}
+
+	return func() {
+		if err := lock.Unlock(); err != nil {
+			log.V(6).Infof("unable to release the lock %q: %v", lockPath, err)
+		}
+	}, nil
+}
+
+func getLockTimeout() time.Duration {
+	value := os.Getenv(constants.PluginCatalogLockTimeout)
+	if value == "" {
+		return DefaultLockTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		log.Warningf("invalid value %q for %s, using the default of %v", value, constants.PluginCatalogLockTimeout, DefaultLockTimeout)
+		return DefaultLockTimeout
+	}
+	return timeout
+}
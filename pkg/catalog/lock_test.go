@@ -0,0 +1,101 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func setupCatalogLockTest(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test-catalog")
+	assert.Nil(t, err)
+	common.DefaultCacheDir = dir
+
+	pluginRootDir, err := os.MkdirTemp("", "test-catalog-plugins")
+	assert.Nil(t, err)
+	common.DefaultPluginRoot = pluginRootDir
+
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+		os.RemoveAll(pluginRootDir)
+	})
+}
+
+func Test_ContextCatalog_Concurrent_Updates(t *testing.T) {
+	assert := assert.New(t)
+	setupCatalogLockTest(t)
+
+	// Two catalogs loaded before any of them is updated must not
+	// overwrite the update of the other one
+	cc1, err := NewContextCatalog("")
+	assert.Nil(err)
+	cc2, err := NewContextCatalog("")
+	assert.Nil(err)
+	assert.Nil(cc1.Upsert(&cli.PluginInfo{Name: "fakeplugin1", InstallationPath: "/path/to/plugin/fakeplugin1"}))
+	assert.Nil(cc2.Upsert(&cli.PluginInfo{Name: "fakeplugin2", InstallationPath: "/path/to/plugin/fakeplugin2"}))
+
+	cc, err := NewContextCatalog("")
+	assert.Nil(err)
+	assert.Equal(2, len(cc.List()))
+
+	// Concurrent updates of the catalog are all saved
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := NewContextCatalog("server")
+			assert.Nil(err)
+			name := fmt.Sprintf("plugin%d", i)
+			assert.Nil(c.Upsert(&cli.PluginInfo{Name: name, InstallationPath: "/path/to/plugin/" + name}))
+		}(i)
+	}
+	wg.Wait()
+
+	cc, err = NewContextCatalog("server")
+	assert.Nil(err)
+	assert.Equal(10, len(cc.List()))
+
+	// No temporary file is left behind
+	files, err := os.ReadDir(common.DefaultCacheDir)
+	assert.Nil(err)
+	for _, f := range files {
+		assert.False(strings.Contains(f.Name(), ".tmp-"), f.Name())
+	}
+}
+
+func Test_CatalogLock_Timeout(t *testing.T) {
+	assert := assert.New(t)
+	setupCatalogLockTest(t)
+	t.Setenv(constants.PluginCatalogLockTimeout, "100ms")
+
+	unlock, err := lockCatalog()
+	assert.Nil(err)
+
+	cc, err := NewContextCatalog("")
+	assert.Nil(err)
+	err = cc.Upsert(&cli.PluginInfo{Name: "fakeplugin1", InstallationPath: "/path/to/plugin/fakeplugin1"})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "timed out after 100ms waiting for another tanzu process to release the lock on the plugin catalog")
+	assert.Contains(err.Error(), filepath.Join(common.DefaultCacheDir, catalogLockFileName))
+
+	// The plugin root lock is independent of the catalog lock
+	unlockRoot, err := LockPluginRoot()
+	assert.Nil(err)
+	unlockRoot()
+
+	unlock()
+	assert.Nil(cc.Upsert(&cli.PluginInfo{Name: "fakeplugin1", InstallationPath: "/path/to/plugin/fakeplugin1"}))
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// CatalogSchemaVersion is the version of the catalog format written by this version of the CLI
+const CatalogSchemaVersion = 1
+
+// catalogMigrations holds the functions migrating the catalog from one
+// schema version to the next one. The function at index N migrates a catalog
+// of schema version N to schema version N+1.
+// A migration must be added to this list every time CatalogSchemaVersion is increased.
+var catalogMigrations = []func(*Catalog) error{
+	migrateCatalogToV1,
+}
+
+// InvalidCatalogError is returned when the catalog file cannot be used
+// by this version of the CLI
+type InvalidCatalogError struct {
+	Reason string
+}
+
+// Error returns the reason why the catalog cannot be used along with its remediation
+func (e *InvalidCatalogError) Error() string {
+	return fmt.Sprintf("%s. Run 'tanzu plugin repair' to rebuild the plugin catalog", e.Reason)
+}
+
+// IsInvalidCatalogError returns true if the error is due to a catalog which cannot be used
+func IsInvalidCatalogError(err error) bool {
+	var invalidCatalogErr *InvalidCatalogError
+	return errors.As(err, &invalidCatalogErr)
+}
+
+// migrateCatalog migrates the catalog to the current schema version
+func migrateCatalog(c *Catalog) error {
+	if c.SchemaVersion > CatalogSchemaVersion {
+		return &InvalidCatalogError{Reason: fmt.Sprintf("the plugin catalog was written by a newer version of the CLI (schema version %d, supported schema version %d)", c.SchemaVersion, CatalogSchemaVersion)}
+	}
+	for c.SchemaVersion < CatalogSchemaVersion {
+		if err := catalogMigrations[c.SchemaVersion](c); err != nil {
+			return &InvalidCatalogError{Reason: fmt.Sprintf("could not migrate the plugin catalog from schema version %d: %v", c.SchemaVersion, err)}
+		}
+		c.SchemaVersion++
+	}
+	return nil
+}
+
+// migrateCatalogToV1 migrates the unversioned catalog.
+// The plugins of the deprecated PluginInfos list are moved to the stand-alone
+// plugins, and the name index is completed for the plugins of all contexts.
+func migrateCatalogToV1(c *Catalog) error {
+	for _, pi := range c.PluginInfos {
+		if pi == nil || pi.InstallationPath == "" {
+			continue
+		}
+		if _, exists := c.IndexByPath[pi.InstallationPath]; !exists {
+			c.IndexByPath[pi.InstallationPath] = *pi
+		}
+		key := PluginNameTarget(pi.Name, pi.Target)
+		if _, exists := c.StandAlonePlugins[key]; !exists {
+			c.StandAlonePlugins[key] = pi.InstallationPath
+		}
+	}
+	c.PluginInfos = nil
+
+	addToNameIndex := func(plugins PluginAssociation) {
+		for key, installationPath := range plugins {
+			if !utils.ContainsString(c.IndexByName[key], installationPath) {
+				c.IndexByName[key] = append(c.IndexByName[key], installationPath)
+			}
+		}
+	}
+	addToNameIndex(c.StandAlonePlugins)
+	for _, plugins := range c.ServerPlugins {
+		addToNameIndex(plugins)
+	}
+	return nil
+}
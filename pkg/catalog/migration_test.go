@@ -0,0 +1,100 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+const unversionedCatalog = `pluginInfos:
+- name: legacy
+  version: v0.1.0
+  installationPath: /path/to/plugin/legacy
+indexByPath:
+  /path/to/plugin/fakeplugin1:
+    name: fakeplugin1
+    version: v1.0.0
+    installationPath: /path/to/plugin/fakeplugin1
+    target: kubernetes
+standAlonePlugins:
+  fakeplugin1_kubernetes: /path/to/plugin/fakeplugin1
+`
+
+func Test_CatalogMigrations(t *testing.T) {
+	assert := assert.New(t)
+
+	// Every schema version must have a migration from the previous one
+	assert.Equal(CatalogSchemaVersion, len(catalogMigrations))
+}
+
+func Test_Catalog_Migration_From_Unversioned(t *testing.T) {
+	assert := assert.New(t)
+	setupCatalogLockTest(t)
+
+	assert.Nil(os.WriteFile(getCatalogCachePath(), []byte(unversionedCatalog), 0644))
+
+	cc, err := NewContextCatalog("")
+	assert.Nil(err)
+	assert.Equal(CatalogSchemaVersion, cc.sharedCatalog.SchemaVersion)
+	assert.Nil(cc.sharedCatalog.PluginInfos)
+	assert.Equal(2, len(cc.List()))
+	pd, exists := cc.Get("legacy")
+	assert.True(exists)
+	assert.Equal("v0.1.0", pd.Version)
+	assert.Equal([]string{"/path/to/plugin/fakeplugin1"}, cc.sharedCatalog.IndexByName["fakeplugin1_kubernetes"])
+
+	// The schema version is written with the next update
+	assert.Nil(cc.Delete("legacy"))
+	b, err := os.ReadFile(getCatalogCachePath())
+	assert.Nil(err)
+	var c Catalog
+	assert.Nil(yaml.Unmarshal(b, &c))
+	assert.Equal(CatalogSchemaVersion, c.SchemaVersion)
+}
+
+func Test_Catalog_Invalid(t *testing.T) {
+	assert := assert.New(t)
+	setupCatalogLockTest(t)
+
+	// Catalog written by a newer version of the CLI
+	assert.Nil(os.WriteFile(getCatalogCachePath(), []byte("schemaVersion: 1000\n"), 0644))
+	_, err := NewContextCatalog("")
+	assert.NotNil(err)
+	assert.True(IsInvalidCatalogError(err))
+	assert.Contains(err.Error(), "schema version 1000")
+	assert.Contains(err.Error(), "tanzu plugin repair")
+
+	// Corrupted catalog
+	assert.Nil(os.WriteFile(getCatalogCachePath(), []byte("indexByPath: [\n"), 0644))
+	_, err = ListPluginsByContext()
+	assert.NotNil(err)
+	assert.True(IsInvalidCatalogError(err))
+
+	// Rebuilding the catalog replaces the invalid catalog
+	plugins := []cli.PluginInfo{
+		{Name: "fakeplugin1", Version: "v1.0.0", InstallationPath: "/path/to/plugin/fakeplugin1_v1"},
+		{Name: "fakeplugin1", Version: "v2.0.0", InstallationPath: "/path/to/plugin/fakeplugin1_v2"},
+		{Name: "fakeplugin2", Version: "v1.0.0", InstallationPath: "/path/to/plugin/fakeplugin2"},
+	}
+	associations := map[string]PluginAssociation{
+		"":       {"fakeplugin1": "/path/to/plugin/fakeplugin1_v2"},
+		"server": {"fakeplugin2": "/path/to/plugin/fakeplugin2"},
+	}
+	assert.Nil(RebuildCatalog(plugins, associations))
+
+	pluginsByContext, err := ListPluginsByContext()
+	assert.Nil(err)
+	assert.Equal(2, len(pluginsByContext))
+	assert.Equal("v2.0.0", pluginsByContext[""][0].Version)
+	assert.Equal("fakeplugin2", pluginsByContext["server"][0].Name)
+	paths, err := ListInstallationPaths()
+	assert.Nil(err)
+	assert.Equal(3, len(paths))
+}
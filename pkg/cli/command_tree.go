@@ -0,0 +1,231 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// maxCommandTreeDepth is the maximum depth of the captured command tree of a plugin
+	maxCommandTreeDepth = 6
+	// maxCommandTreeSize is the maximum number of commands captured for a plugin
+	maxCommandTreeSize = 300
+)
+
+var (
+	ansiEscapeRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+	flagUsageRegexp  = regexp.MustCompile(`^(?:-(\S), )?--(\S+?)(?: (\S+))?(?:\s{2,}(.*))?$`)
+)
+
+// CommandNode describes a command of a plugin along with its sub-commands
+type CommandNode struct {
+	// Name is the name of the command
+	Name string `json:"name" yaml:"name"`
+	// Short is the short description of the command
+	Short string `json:"short,omitempty" yaml:"short,omitempty"`
+	// Flags are the flags of the command, including the inherited flags
+	Flags []CommandFlag `json:"flags,omitempty" yaml:"flags,omitempty"`
+	// Commands are the sub-commands of the command
+	Commands []*CommandNode `json:"commands,omitempty" yaml:"commands,omitempty"`
+}
+
+// CommandFlag describes a flag of a command
+type CommandFlag struct {
+	// Name is the name of the flag
+	Name string `json:"name" yaml:"name"`
+	// Shorthand is the one-letter abbreviation of the flag, if any
+	Shorthand string `json:"shorthand,omitempty" yaml:"shorthand,omitempty"`
+	// Type is the placeholder of the value of the flag, e.g. "string",
+	// or empty for a boolean flag
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Usage is the description of the flag
+	Usage string `json:"usage,omitempty" yaml:"usage,omitempty"`
+	// Inherited is true for the flags inherited from a parent command
+	Inherited bool `json:"inherited,omitempty" yaml:"inherited,omitempty"`
+}
+
+// CommandHelp is the help output of the commands of a plugin, keyed by
+// the path of the command without the plugin name, e.g. "cluster list"
+// for the "tanzu <plugin> cluster list" command, "" being the plugin command.
+// The help is kept outside of the catalog, as it is only read by the help
+// and docs commands.
+type CommandHelp map[string]string
+
+// HelpRunner returns the help output of a plugin command given the
+// arguments of the command, e.g. ["create", "-h"]
+type HelpRunner func(args ...string) ([]byte, error)
+
+// CaptureCommandTree captures the command tree of a plugin, and the help
+// of its commands, from the help output of each of its commands.
+// An error is returned if the plugin has more than maxCommandTreeSize commands.
+func CaptureCommandTree(pluginName string, runHelp HelpRunner) (*CommandNode, CommandHelp, error) {
+	root := &CommandNode{Name: pluginName}
+	help := CommandHelp{}
+	count := 0
+	if err := captureCommand(root, nil, runHelp, help, &count); err != nil {
+		return nil, nil, err
+	}
+	return root, help, nil
+}
+
+func captureCommand(node *CommandNode, path []string, runHelp HelpRunner, help CommandHelp, count *int) error {
+	*count++
+	output, err := runHelp(append(append([]string{}, path...), "-h")...)
+	if err != nil {
+		return err
+	}
+	text := ansiEscapeRegexp.ReplaceAllString(string(output), "")
+	help[strings.Join(path, " ")] = text
+	var commands []*CommandNode
+	commands, node.Flags = parseHelp(text)
+
+	if len(path) >= maxCommandTreeDepth {
+		return nil
+	}
+	for _, c := range commands {
+		// An incomplete tree would hide the commands of the plugin from
+		// the completion, which then falls back to running the plugin
+		if *count >= maxCommandTreeSize {
+			return errors.Errorf("the plugin has more than %d commands", maxCommandTreeSize)
+		}
+		if err := captureCommand(c, append(append([]string{}, path...), c.Name), runHelp, help, count); err != nil {
+			return err
+		}
+		node.Commands = append(node.Commands, c)
+	}
+	return nil
+}
+
+// parseHelp returns the sub-commands and the flags listed in the help output of a command
+func parseHelp(help string) ([]*CommandNode, []CommandFlag) {
+	var commands []*CommandNode
+	var flags []CommandFlag
+	section := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(help))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			section = strings.TrimSpace(line)
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		switch section {
+		case "Available Commands:":
+			fields := strings.Fields(trimmed)
+			if fields[0] == "help" || fields[0] == "completion" {
+				continue
+			}
+			commands = append(commands, &CommandNode{Name: fields[0], Short: strings.Join(fields[1:], " ")})
+		case "Flags:", "Global Flags:":
+			if !strings.HasPrefix(trimmed, "-") {
+				// Continuation of the usage of the previous flag
+				if len(flags) > 0 {
+					flags[len(flags)-1].Usage += " " + trimmed
+				}
+				continue
+			}
+			if m := flagUsageRegexp.FindStringSubmatch(trimmed); m != nil {
+				flags = append(flags, CommandFlag{Name: m[2], Shorthand: m[1], Type: m[3], Usage: m[4], Inherited: section == "Global Flags:"})
+			}
+		}
+	}
+	return commands, flags
+}
+
+// CommandHelpPathFromPluginPath returns the path of the file holding the
+// help of the commands of the plugin binary at the given path
+func CommandHelpPathFromPluginPath(pluginPath string) string {
+	return filepath.Join(filepath.Dir(pluginPath), "help", filepath.Base(pluginPath)+".json")
+}
+
+// SaveCommandHelp saves the help of the commands of the plugin binary at the given path
+func SaveCommandHelp(pluginPath string, help CommandHelp) error {
+	helpPath := CommandHelpPathFromPluginPath(pluginPath)
+	if err := os.MkdirAll(filepath.Dir(helpPath), 0755); err != nil {
+		return errors.Wrap(err, "unable to create the plugin help directory")
+	}
+	b, err := json.Marshal(help)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the plugin help")
+	}
+	return errors.Wrap(os.WriteFile(helpPath, b, 0644), "unable to save the plugin help")
+}
+
+// loadCommandHelp reads the help of the commands of the plugin binary at the given path
+func loadCommandHelp(pluginPath string) (CommandHelp, error) {
+	b, err := os.ReadFile(CommandHelpPathFromPluginPath(pluginPath))
+	if err != nil {
+		return nil, err
+	}
+	help := CommandHelp{}
+	if err := json.Unmarshal(b, &help); err != nil {
+		return nil, errors.Wrap(err, "unable to read the plugin help")
+	}
+	return help, nil
+}
+
+// findCommand returns the command of the tree matching the arguments,
+// and false if an argument is not a sub-command
+func (n *CommandNode) findCommand(args []string) (*CommandNode, bool) {
+	node := n
+	for _, arg := range args {
+		var next *CommandNode
+		for _, c := range node.Commands {
+			if c.Name == arg {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return node, false
+		}
+		node = next
+	}
+	return node, true
+}
+
+// complete returns the completions of the sub-commands and the flags of the
+// command tree. The completions of the arguments of a command depend on the
+// plugin and false is returned for them.
+func (n *CommandNode) complete(args []string, toComplete string) ([]string, cobra.ShellCompDirective, bool) {
+	node, found := n.findCommand(args)
+	if !found || strings.Contains(toComplete, "=") {
+		return nil, cobra.ShellCompDirectiveDefault, false
+	}
+
+	var completions []string
+	if strings.HasPrefix(toComplete, "-") {
+		for _, f := range node.Flags {
+			for _, name := range []string{"--" + f.Name, "-" + f.Shorthand} {
+				if name != "-" && strings.HasPrefix(name, toComplete) {
+					completions = append(completions, name+"\t"+f.Usage)
+				}
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp, true
+	}
+
+	if len(node.Commands) == 0 {
+		return nil, cobra.ShellCompDirectiveDefault, false
+	}
+	for _, c := range node.Commands {
+		if strings.HasPrefix(c.Name, toComplete) {
+			completions = append(completions, c.Name+"\t"+c.Short)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp, true
+}
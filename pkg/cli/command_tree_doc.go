@@ -0,0 +1,177 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// helpSections are the section headers of the help output of the plugin commands
+var helpSections = map[string]bool{
+	"Usage:":                  true,
+	"Aliases:":                true,
+	"Examples:":               true,
+	"Available Commands:":     true,
+	"Flags:":                  true,
+	"Global Flags:":           true,
+	"Additional help topics:": true,
+}
+
+// docFlagValue is the value of a flag of a plugin command. It only
+// describes the type of the flag for the generation of the docs.
+type docFlagValue string
+
+func (v docFlagValue) String() string {
+	return ""
+}
+
+func (v docFlagValue) Set(string) error {
+	return nil
+}
+
+func (v docFlagValue) Type() string {
+	if v == "" {
+		return "bool"
+	}
+	return string(v)
+}
+
+// GetDocCmdForPlugin returns the commands of a plugin, built from the command
+// tree and the help captured when the plugin was installed, so that the docs
+// of the plugin are generated without running it
+func GetDocCmdForPlugin(p *PluginInfo) (*cobra.Command, error) {
+	if p.CommandTree == nil {
+		return nil, errors.Errorf("the command tree of plugin %q is not available", p.Name)
+	}
+	help, err := loadCommandHelp(p.InstallationPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "the help of plugin %q is not available", p.Name)
+	}
+
+	persistentFlags := map[*CommandNode]map[string]CommandFlag{}
+	findPersistentFlags(p.CommandTree, nil, persistentFlags)
+	cmd := newDocCmd(p.CommandTree, []string{p.Name}, help, persistentFlags)
+	if cmd.Short == "" {
+		cmd.Short = p.Description
+		if cmd.Long == cmd.Short {
+			cmd.Long = ""
+		}
+	}
+	return cmd, nil
+}
+
+// findPersistentFlags finds the command defining each of the flags inherited
+// by the sub-commands, i.e., the closest parent command having the flag
+func findPersistentFlags(node *CommandNode, parents []*CommandNode, persistentFlags map[*CommandNode]map[string]CommandFlag) {
+	for _, f := range node.Flags {
+		if !f.Inherited {
+			continue
+		}
+		owner := node
+		if len(parents) > 0 {
+			owner = parents[0]
+		}
+		for i := len(parents) - 1; i >= 0; i-- {
+			if hasLocalFlag(parents[i], f.Name) {
+				owner = parents[i]
+				break
+			}
+		}
+		if persistentFlags[owner] == nil {
+			persistentFlags[owner] = map[string]CommandFlag{}
+		}
+		f.Inherited = false
+		persistentFlags[owner][f.Name] = f
+	}
+
+	parents = append(append([]*CommandNode{}, parents...), node)
+	for _, c := range node.Commands {
+		findPersistentFlags(c, parents, persistentFlags)
+	}
+}
+
+func hasLocalFlag(node *CommandNode, name string) bool {
+	for _, f := range node.Flags {
+		if f.Name == name && !f.Inherited {
+			return true
+		}
+	}
+	return false
+}
+
+func newDocCmd(node *CommandNode, path []string, help CommandHelp, persistentFlags map[*CommandNode]map[string]CommandFlag) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   node.Name,
+		Short: node.Short,
+	}
+	setDocFromHelp(cmd, path, help[strings.Join(path[1:], " ")])
+
+	// The help flag is added by cobra
+	for _, f := range node.Flags {
+		if _, persistent := persistentFlags[node][f.Name]; f.Inherited || persistent || f.Name == "help" {
+			continue
+		}
+		addDocFlag(cmd.Flags(), f)
+	}
+	for _, f := range persistentFlags[node] {
+		addDocFlag(cmd.PersistentFlags(), f)
+	}
+
+	for _, c := range node.Commands {
+		cmd.AddCommand(newDocCmd(c, append(append([]string{}, path...), c.Name), help, persistentFlags))
+	}
+	return cmd
+}
+
+func addDocFlag(flags *pflag.FlagSet, f CommandFlag) {
+	flag := flags.VarPF(docFlagValue(f.Type), f.Name, f.Shorthand, f.Usage)
+	if f.Type == "" {
+		flag.NoOptDefVal = "true"
+	}
+}
+
+// setDocFromHelp sets the long description, the usage and the examples of a
+// command from its help output. The help output is the description of the
+// command followed by its usage, as formatted by the plugin runtime.
+func setDocFromHelp(cmd *cobra.Command, path []string, text string) {
+	if text == "" {
+		cmd.Run = func(cmd *cobra.Command, args []string) {}
+		return
+	}
+
+	commandPath := "tanzu " + strings.Join(path, " ")
+	var description, examples []string
+	section := ""
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if helpSections[line] {
+			section = line
+			continue
+		}
+		switch section {
+		case "":
+			description = append(description, line)
+		case "Usage:":
+			usage := strings.TrimSpace(line)
+			if usage == commandPath+" [command]" || !strings.HasPrefix(usage, commandPath) {
+				continue
+			}
+			cmd.Use = strings.TrimSpace(cmd.Use + " " + strings.TrimSpace(strings.TrimPrefix(usage, commandPath)))
+			cmd.Run = func(cmd *cobra.Command, args []string) {}
+		case "Examples:":
+			examples = append(examples, line)
+		}
+	}
+
+	if long := strings.TrimSpace(strings.Join(description, "\n")); long != cmd.Short {
+		cmd.Long = long
+	}
+	if len(examples) > 0 {
+		examples[0] = strings.TrimPrefix(examples[0], "  ")
+		cmd.Example = strings.TrimRight(strings.Join(examples, "\n"), "\n")
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+	"github.com/stretchr/testify/assert"
+)
+
+var testHelpOutputs = map[string]string{
+	"": `Manage clusters
+
+Usage:
+  tanzu cluster [command]
+
+Available Commands:
+  completion  Generate the autocompletion script
+  create      Create a cluster
+  help        Help about any command
+  list        List clusters
+
+Flags:
+  -h, --help      help for cluster
+  -v, --verbose int32   Number for the log level
+                        verbosity (0-9)
+`,
+	"create": "\x1b[1mCreate a cluster\x1b[0m\n\nUsage:\n  tanzu cluster create NAME [flags]\n\nExamples:\n  # Create a cluster\n  tanzu cluster create my-cluster --file cluster.yaml\n\nFlags:\n  -f, --file string   Cluster configuration file\n  -h, --help          help for create\n      --dry-run       Print the cluster configuration\n\nGlobal Flags:\n  -v, --verbose int32   Number for the log level verbosity (0-9)\n",
+	"list": `List clusters
+
+Usage:
+  tanzu cluster list [flags]
+
+Flags:
+  -h, --help   help for list
+`,
+}
+
+func testHelpRunner(args ...string) ([]byte, error) {
+	output, exists := testHelpOutputs[strings.Join(args[:len(args)-1], " ")]
+	if !exists {
+		return nil, errors.New("unknown command")
+	}
+	return []byte(output), nil
+}
+
+func TestCaptureCommandTree(t *testing.T) {
+	assert := assert.New(t)
+
+	tree, help, err := CaptureCommandTree("cluster", testHelpRunner)
+	assert.Nil(err)
+	assert.Equal("cluster", tree.Name)
+	assert.Equal(2, len(tree.Commands))
+	assert.Equal([]CommandFlag{
+		{Name: "help", Shorthand: "h", Usage: "help for cluster"},
+		{Name: "verbose", Shorthand: "v", Type: "int32", Usage: "Number for the log level verbosity (0-9)"},
+	}, tree.Flags)
+
+	create := tree.Commands[0]
+	assert.Equal("create", create.Name)
+	assert.Equal("Create a cluster", create.Short)
+	assert.Equal([]CommandFlag{
+		{Name: "file", Shorthand: "f", Type: "string", Usage: "Cluster configuration file"},
+		{Name: "help", Shorthand: "h", Usage: "help for create"},
+		{Name: "dry-run", Usage: "Print the cluster configuration"},
+		{Name: "verbose", Shorthand: "v", Type: "int32", Usage: "Number for the log level verbosity (0-9)", Inherited: true},
+	}, create.Flags)
+	assert.Equal("list", tree.Commands[1].Name)
+
+	// The help is kept apart from the command tree
+	assert.Equal(3, len(help))
+	assert.Equal(testHelpOutputs[""], help[""])
+	assert.True(strings.HasPrefix(help["create"], "Create a cluster\n"))
+	assert.Equal(testHelpOutputs["list"], help["list"])
+
+	_, _, err = CaptureCommandTree("cluster", func(args ...string) ([]byte, error) {
+		return nil, errors.New("failed")
+	})
+	assert.NotNil(err)
+}
+
+func TestCaptureCommandTreeTooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	var help strings.Builder
+	help.WriteString("Usage:\n  tanzu big [command]\n\nAvailable Commands:\n")
+	for i := 0; i < maxCommandTreeSize; i++ {
+		fmt.Fprintf(&help, "  cmd%d  Command %d\n", i, i)
+	}
+	_, _, err := CaptureCommandTree("big", func(args ...string) ([]byte, error) {
+		if len(args) == 1 {
+			return []byte(help.String()), nil
+		}
+		return []byte("Usage:\n  tanzu big cmd\n"), nil
+	})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "more than 300 commands")
+}
+
+func TestCommandTreeCompletion(t *testing.T) {
+	assert := assert.New(t)
+
+	tree, _, err := CaptureCommandTree("cluster", testHelpRunner)
+	assert.Nil(err)
+
+	completions, directive, found := tree.complete(nil, "")
+	assert.True(found)
+	assert.Equal([]string{"create\tCreate a cluster", "list\tList clusters"}, completions)
+	assert.Equal(cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, _, found = tree.complete(nil, "l")
+	assert.True(found)
+	assert.Equal([]string{"list\tList clusters"}, completions)
+
+	completions, _, found = tree.complete([]string{"create"}, "--d")
+	assert.True(found)
+	assert.Equal([]string{"--dry-run\tPrint the cluster configuration"}, completions)
+
+	completions, _, found = tree.complete([]string{"create"}, "-f")
+	assert.True(found)
+	assert.Equal([]string{"-f\tCluster configuration file"}, completions)
+
+	// The arguments of the commands and the flag values are completed by the plugin
+	_, _, found = tree.complete([]string{"create"}, "")
+	assert.False(found)
+	_, _, found = tree.complete([]string{"create", "--file"}, "")
+	assert.False(found)
+	_, _, found = tree.complete([]string{"create"}, "--file=")
+	assert.False(found)
+}
+
+func TestPluginHelpFromCommandTree(t *testing.T) {
+	assert := assert.New(t)
+
+	tree, help, err := CaptureCommandTree("cluster", testHelpRunner)
+	assert.Nil(err)
+	pluginPath := filepath.Join(t.TempDir(), "v1.0.0_digest_global")
+	assert.Nil(SaveCommandHelp(pluginPath, help))
+	cmd := GetCmdForPlugin(&PluginInfo{Name: "cluster", InstallationPath: pluginPath, CommandTree: tree})
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	os.Args = []string{"tanzu", "help", "cluster", "list"}
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.HelpFunc()(cmd, nil)
+	assert.Equal(testHelpOutputs["list"], out.String())
+
+	completions, directive := cmd.ValidArgsFunction(cmd, []string{"create"}, "--f")
+	assert.Equal([]string{"--file\tCluster configuration file"}, completions)
+	assert.Equal(cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestGetDocCmdForPlugin(t *testing.T) {
+	assert := assert.New(t)
+
+	tree, help, err := CaptureCommandTree("cluster", testHelpRunner)
+	assert.Nil(err)
+	pluginPath := filepath.Join(t.TempDir(), "v1.0.0_digest_global")
+	p := &PluginInfo{Name: "cluster", Description: "Manage clusters", InstallationPath: pluginPath, CommandTree: tree}
+
+	// The help of the commands is needed
+	_, err = GetDocCmdForPlugin(p)
+	assert.NotNil(err)
+
+	assert.Nil(SaveCommandHelp(pluginPath, help))
+	cmd, err := GetDocCmdForPlugin(p)
+	assert.Nil(err)
+	tanzuCmd := &cobra.Command{Use: "tanzu"}
+	tanzuCmd.AddCommand(cmd)
+
+	assert.Equal("Manage clusters", cmd.Short)
+	assert.Empty(cmd.Long)
+	assert.False(cmd.Runnable())
+	assert.NotNil(cmd.PersistentFlags().Lookup("verbose"))
+
+	create, _, err := cmd.Find([]string{"create"})
+	assert.Nil(err)
+	assert.Equal("create NAME [flags]", create.Use)
+	assert.Equal("Create a cluster", create.Short)
+	assert.Empty(create.Long)
+	assert.True(create.Runnable())
+	assert.Equal("# Create a cluster\n  tanzu cluster create my-cluster --file cluster.yaml", create.Example)
+	assert.Equal("string", create.Flags().Lookup("file").Value.Type())
+	assert.Equal("bool", create.Flags().Lookup("dry-run").Value.Type())
+	assert.NotNil(create.InheritedFlags().Lookup("verbose"))
+
+	var out bytes.Buffer
+	assert.Nil(doc.GenMarkdown(create, &out))
+	assert.Contains(out.String(), "```\ntanzu cluster create NAME [flags]\n```")
+	assert.Contains(out.String(), "  -f, --file string   Cluster configuration file\n")
+	assert.Contains(out.String(), "### Options inherited from parent commands")
+	assert.Contains(out.String(), "* [tanzu cluster](tanzu_cluster.md)\t - Manage clusters")
+}
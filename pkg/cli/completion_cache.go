@@ -0,0 +1,152 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const (
+	// completionCacheDirName is the directory of the cache directory holding the
+	// shell completions of the plugins, with one sub-directory per plugin
+	completionCacheDirName = "completion"
+
+	// DefaultCompletionCacheTTL is the default duration during which the
+	// shell completions obtained from a plugin are reused
+	DefaultCompletionCacheTTL = 30 * time.Second
+
+	// CompletionCacheMarker is the prefix of a completion line a plugin can output
+	// to control the caching of its completions. It is followed by "false" to prevent
+	// the completions from being cached, or by the duration during which the
+	// completions can be reused, e.g. "_tanzu_completion_cache_ 5m".
+	// The line is removed from the completions.
+	CompletionCacheMarker = "_tanzu_completion_cache_"
+)
+
+// completionCacheEntry is the cached completion of a command line
+type completionCacheEntry struct {
+	Expiration time.Time                `json:"expiration"`
+	Lines      []string                 `json:"lines"`
+	Directive  cobra.ShellCompDirective `json:"directive"`
+}
+
+func getCompletionCacheDir(pluginName string) string {
+	return filepath.Join(common.DefaultCacheDir, completionCacheDirName, pluginName)
+}
+
+// ClearCompletionCache removes the cached completions of a plugin, e.g., when
+// the plugin is upgraded or deleted
+func ClearCompletionCache(pluginName string) {
+	if err := os.RemoveAll(getCompletionCacheDir(pluginName)); err != nil {
+		log.V(6).Infof("unable to clear the completion cache of plugin %q: %v", pluginName, err)
+	}
+}
+
+// getCompletionCacheTTL returns the duration during which the completions
+// are reused, or zero if the completion cache is disabled
+func getCompletionCacheTTL() time.Duration {
+	value := os.Getenv(constants.CompletionCacheTTL)
+	if value == "" {
+		return DefaultCompletionCacheTTL
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		log.V(6).Infof("invalid value %q for %s, using the default of %v", value, constants.CompletionCacheTTL, DefaultCompletionCacheTTL)
+		return DefaultCompletionCacheTTL
+	}
+	return ttl
+}
+
+// completionCacheKey returns the key of the completion of a command line of a plugin.
+// The key depends on the plugin binary, so that upgrading a plugin invalidates its
+// cached completions, and on the active contexts, as the completions of a plugin
+// often depend on the resources of the context.
+func completionCacheKey(p *PluginInfo, args []string) string {
+	binary := p.Digest
+	if binary == "" {
+		binary = p.InstallationPath
+	}
+	contexts, err := GetAllCurrentContextsList()
+	if err != nil {
+		log.V(6).Infof("unable to get the current contexts for the completion cache: %v", err)
+	}
+	sort.Strings(contexts)
+
+	h := sha256.New()
+	for _, part := range [][]string{{binary}, contexts, args} {
+		b, _ := json.Marshal(part)
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// getCachedCompletion returns the cached completion of the key, if any and not expired
+func getCachedCompletion(pluginName, key string) (*completionCacheEntry, bool) {
+	path := filepath.Join(getCompletionCacheDir(pluginName), key)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry completionCacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil || time.Now().After(entry.Expiration) {
+		_ = os.Remove(path)
+		return nil, false
+	}
+	return &entry, true
+}
+
+// cacheCompletion caches the completion of the key if the completion cache is enabled.
+// The completions are not cached if the plugin marked them as not cacheable, and are cached
+// for the duration requested by the plugin, if any. The cache marker is removed from the
+// returned completions.
+func cacheCompletion(pluginName, key string, lines []string, directive cobra.ShellCompDirective, ttl time.Duration) []string {
+	enabled := ttl > 0
+	var completions []string
+	for _, line := range lines {
+		if !strings.HasPrefix(line, CompletionCacheMarker) {
+			completions = append(completions, line)
+			continue
+		}
+		if !enabled {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(line, CompletionCacheMarker))
+		if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+			ttl = d
+		} else {
+			ttl = 0
+		}
+	}
+	if ttl == 0 || directive&cobra.ShellCompDirectiveError != 0 {
+		return completions
+	}
+
+	b, err := json.Marshal(&completionCacheEntry{
+		Expiration: time.Now().Add(ttl),
+		Lines:      completions,
+		Directive:  directive,
+	})
+	if err == nil {
+		err = utils.SaveFile(filepath.Join(getCompletionCacheDir(pluginName), key), b)
+	}
+	if err != nil {
+		log.V(6).Infof("unable to cache the completion of plugin %q: %v", pluginName, err)
+	}
+	return completions
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestCompletionCache(t *testing.T) {
+	assert := assert.New(t)
+	setupTestConfig(t)
+	originalCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = t.TempDir()
+	defer func() { common.DefaultCacheDir = originalCacheDir }()
+
+	// The plugin records every invocation and outputs the additional completion lines of the test
+	counter := filepath.Join(t.TempDir(), "invocations")
+	extra := filepath.Join(t.TempDir(), "extra")
+	assert.Nil(os.WriteFile(extra, []byte{}, 0o600))
+	script := fmt.Sprintf("echo x >> %s\necho \"cluster1\tfirst\"\ncat %s\necho :4", counter, extra)
+	pi := &PluginInfo{Name: "fakefoo", Digest: "digest1", InstallationPath: setupFakePluginScript(t, "fakefoo", script)}
+	cmd := GetCmdForPlugin(pi)
+
+	invocations := func() int {
+		b, err := os.ReadFile(counter)
+		if err != nil {
+			return 0
+		}
+		return len(b) / 2
+	}
+
+	for i := 0; i < 2; i++ {
+		completions, directive := cmd.ValidArgsFunction(cmd, []string{"get"}, "clu")
+		assert.Equal([]string{"cluster1\tfirst"}, completions)
+		assert.Equal(cobra.ShellCompDirectiveNoFileComp, directive)
+	}
+	assert.Equal(1, invocations())
+
+	// Other arguments are not completed from the cache
+	cmd.ValidArgsFunction(cmd, []string{"get"}, "c")
+	assert.Equal(2, invocations())
+
+	// Upgrading the plugin invalidates the cache
+	pi.Digest = "digest2"
+	cmd.ValidArgsFunction(cmd, []string{"get"}, "clu")
+	assert.Equal(3, invocations())
+	cmd.ValidArgsFunction(cmd, []string{"get"}, "clu")
+	assert.Equal(3, invocations())
+	ClearCompletionCache("fakefoo")
+	cmd.ValidArgsFunction(cmd, []string{"get"}, "clu")
+	assert.Equal(4, invocations())
+
+	// The plugin can mark its completions as not cacheable
+	assert.Nil(os.WriteFile(extra, []byte(CompletionCacheMarker+" false\n"), 0o600))
+	for i := 0; i < 2; i++ {
+		completions, _ := cmd.ValidArgsFunction(cmd, []string{"describe"}, "")
+		assert.Equal([]string{"cluster1\tfirst"}, completions)
+	}
+	assert.Equal(6, invocations())
+
+	// The cache can be disabled
+	assert.Nil(os.WriteFile(extra, []byte(CompletionCacheMarker+" 1h\n"), 0o600))
+	t.Setenv(constants.CompletionCacheTTL, "0")
+	for i := 0; i < 2; i++ {
+		completions, _ := cmd.ValidArgsFunction(cmd, []string{"delete"}, "")
+		assert.Equal([]string{"cluster1\tfirst"}, completions)
+	}
+	assert.Equal(8, invocations())
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"github.com/pkg/errors"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// contextOverride is the name of the context used instead of the
+// current context of its target for the invocation of the CLI
+var contextOverride string
+
+// SetContextOverride sets the name of the context to use instead of the current
+// context of its target for the invocation of the CLI. The current contexts of
+// the configuration file are not modified. An empty name removes the override.
+func SetContextOverride(name string) error {
+	if name != "" {
+		if _, err := configlib.GetContext(name); err != nil {
+			return errors.Errorf("context %q not found, use `tanzu context list` to find the available contexts", name)
+		}
+	}
+	contextOverride = name
+	return nil
+}
+
+// ContextOverride returns the name of the context overriding the
+// current context of its target, if any.
+func ContextOverride() string {
+	return contextOverride
+}
+
+// GetAllCurrentContextsMap returns the current context of every target,
+// the context override replacing the current context of its target.
+func GetAllCurrentContextsMap() (map[configtypes.Target]*configtypes.Context, error) {
+	currentContexts, err := configlib.GetAllCurrentContextsMap()
+	if err != nil {
+		return nil, err
+	}
+	if contextOverride == "" {
+		return currentContexts, nil
+	}
+
+	ctx, err := configlib.GetContext(contextOverride)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get context %q", contextOverride)
+	}
+	if currentContexts == nil {
+		currentContexts = make(map[configtypes.Target]*configtypes.Context)
+	}
+	currentContexts[ctx.Target] = ctx
+	return currentContexts, nil
+}
+
+// GetAllCurrentContextsList returns the names of the current contexts of all
+// the targets, the context override replacing the current context of its target.
+func GetAllCurrentContextsList() ([]string, error) {
+	currentContexts, err := GetAllCurrentContextsMap()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, ctx := range currentContexts {
+		names = append(names, ctx.Name)
+	}
+	return names, nil
+}
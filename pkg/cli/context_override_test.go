@@ -0,0 +1,65 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestContextOverride(t *testing.T) {
+	assert := assert.New(t)
+	setupTestConfig(t)
+	defer func() { contextOverride = "" }()
+
+	for _, name := range []string{"other-k8s", "current-k8s"} {
+		err := configlib.SetContext(&configtypes.Context{
+			Name:        name,
+			Target:      configtypes.TargetK8s,
+			ClusterOpts: &configtypes.ClusterServer{Path: "/tmp/" + name, Context: name},
+		}, true)
+		assert.Nil(err)
+	}
+
+	names, err := GetAllCurrentContextsList()
+	assert.Nil(err)
+	assert.Equal([]string{"current-k8s"}, names)
+
+	err = SetContextOverride("missing")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `context "missing" not found`)
+
+	assert.Nil(SetContextOverride("other-k8s"))
+	assert.Equal("other-k8s", ContextOverride())
+	currentContexts, err := GetAllCurrentContextsMap()
+	assert.Nil(err)
+	assert.Equal("other-k8s", currentContexts[configtypes.TargetK8s].Name)
+
+	// The context is passed to the plugin
+	runner := NewRunner("fakefoo", setupFakePluginScript(t, "fakefoo", "env | grep ^TANZU_"), nil)
+	stdout, _, err := runner.RunOutput(context.Background())
+	assert.Nil(err)
+	env := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Contains(env, constants.ContextOverride+"=other-k8s")
+	assert.Contains(env, constants.PluginEnvContextK8s+"=other-k8s")
+	assert.Contains(env, constants.PluginEnvKubeconfig+"=/tmp/other-k8s")
+
+	// The current context of the configuration is not modified
+	ctx, err := configlib.GetCurrentContext(configtypes.TargetK8s)
+	assert.Nil(err)
+	assert.Equal("current-k8s", ctx.Name)
+
+	assert.Nil(SetContextOverride(""))
+	currentContexts, err = GetAllCurrentContextsMap()
+	assert.Nil(err)
+	assert.Equal("current-k8s", currentContexts[configtypes.TargetK8s].Name)
+}
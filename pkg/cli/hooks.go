@@ -0,0 +1,140 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/clientconfighelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// hooksSetting is the CLI setting holding the hooks run around the plugin commands
+const hooksSetting = "hooks"
+
+// allCommands is the command path of the hooks run around every plugin command
+const allCommands = "*"
+
+// Hook is a user-configured shell command run before or after the plugin
+// commands matching a command path
+type Hook struct {
+	// Command is the command path the hook applies to, without the tanzu prefix:
+	// the name of a plugin optionally followed by the leading arguments of the
+	// plugin command, e.g. "cluster" or "cluster create". "*" matches all the
+	// plugin commands.
+	Command string `yaml:"command" json:"command"`
+	// Pre is the shell command run before the plugin command.
+	// The plugin command is not run if the pre-command hook fails.
+	Pre string `yaml:"pre,omitempty" json:"pre,omitempty"`
+	// Post is the shell command run after the plugin command,
+	// whether the plugin command succeeds or not.
+	Post string `yaml:"post,omitempty" json:"post,omitempty"`
+}
+
+// GetHooks returns the configured hooks in the order they are run
+func GetHooks() ([]Hook, error) {
+	var hooks []Hook
+	if _, err := clientconfighelpers.GetCLISetting(hooksSetting, &hooks); err != nil {
+		return nil, errors.Wrap(err, "unable to read the command hooks")
+	}
+	return hooks, nil
+}
+
+// matches returns true if the hook applies to the command line
+func (h *Hook) matches(commandLine []string) bool {
+	path := strings.Fields(h.Command)
+	if len(path) == 1 && path[0] == allCommands {
+		return true
+	}
+	if len(path) == 0 || len(path) > len(commandLine) {
+		return false
+	}
+	for i := range path {
+		if path[i] != commandLine[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// runWithHooks runs the pre-command hooks matching the plugin command, the
+// plugin command if all the pre-command hooks succeed, and the post-command hooks.
+func runWithHooks(pluginName string, args []string, run func() error) error {
+	configured, err := GetHooks()
+	if err != nil {
+		return err
+	}
+	commandLine := append([]string{pluginName}, args...)
+	var matching []Hook
+	for i := range configured {
+		if configured[i].matches(commandLine) {
+			matching = append(matching, configured[i])
+		}
+	}
+	if len(matching) == 0 {
+		return run()
+	}
+
+	env := append(pluginEnvironment(),
+		constants.HookEnvPlugin+"="+pluginName,
+		constants.HookEnvCommand+"="+strings.Join(commandLine, " "))
+	env = env[:len(env):len(env)]
+
+	preEnv := append(env, constants.HookEnvPhase+"=pre")
+	for i := range matching {
+		if matching[i].Pre == "" {
+			continue
+		}
+		if err := runHook(matching[i].Pre, preEnv); err != nil {
+			return errors.Wrapf(err, "the pre-command hook %q failed, the command was not run", matching[i].Pre)
+		}
+	}
+
+	runErr := run()
+
+	exitCode := 0
+	if runErr != nil {
+		exitCode = 1
+		var exitErr *PluginExitError
+		if errors.As(runErr, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+	}
+	postEnv := append(env, constants.HookEnvPhase+"=post", constants.HookEnvExitCode+"="+strconv.Itoa(exitCode))
+	for i := range matching {
+		if matching[i].Post == "" {
+			continue
+		}
+		if err := runHook(matching[i].Post, postEnv); err != nil {
+			log.Warningf("the post-command hook %q failed: %v", matching[i].Post, err)
+		}
+	}
+	return runErr
+}
+
+// runHook runs the hook using the shell of the platform
+func runHook(command string, env []string) error {
+	args := append(append([]string{}, hookShell[1:]...), command)
+	cmd := exec.Command(hookShell[0], args...) //nolint:gosec
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("exit code %d", exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// setupHooks writes the hooks, in YAML, to the CLI configuration
+func setupHooks(t *testing.T, content string) {
+	var hooks interface{}
+	assert.Nil(t, yaml.Unmarshal([]byte(content), &hooks))
+	b, err := yaml.Marshal(map[string]interface{}{"clientOptions": map[string]interface{}{"cli": map[string]interface{}{"hooks": hooks}}})
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(os.Getenv("TANZU_CONFIG"), b, 0o600))
+}
+
+func TestHookMatches(t *testing.T) {
+	tests := []struct {
+		command  string
+		expected bool
+	}{
+		{command: "*", expected: true},
+		{command: "cluster", expected: true},
+		{command: "cluster create", expected: true},
+		{command: "cluster  create my-cluster", expected: true},
+		{command: "cluster delete"},
+		{command: "cluster create my-cluster --plan"},
+		{command: "management-cluster"},
+		{command: ""},
+	}
+	for _, spec := range tests {
+		h := Hook{Command: spec.command}
+		assert.Equal(t, spec.expected, h.matches([]string{"cluster", "create", "my-cluster"}), spec.command)
+	}
+}
+
+func TestRunWithHooks(t *testing.T) {
+	assert := assert.New(t)
+	setupTestConfig(t)
+
+	out := filepath.Join(t.TempDir(), "hooks.log")
+	setupHooks(t, fmt.Sprintf(`
+- command: "*"
+  pre: echo "pre $TANZU_CLI_HOOK_PHASE $TANZU_CLI_HOOK_PLUGIN" >> %[1]s
+- command: fakefoo say
+  post: echo "post $TANZU_CLI_HOOK_COMMAND $TANZU_CLI_HOOK_EXIT_CODE" >> %[1]s
+- command: other
+  pre: exit 1
+`, out))
+
+	path := setupFakePluginScript(t, "fakefoo", "exit 3")
+	cmd := GetCmdForPlugin(&PluginInfo{Name: "fakefoo", InstallationPath: path})
+
+	err := cmd.RunE(cmd, []string{"say", "hello"})
+	var exitErr *PluginExitError
+	assert.True(errors.As(err, &exitErr))
+	assert.Equal(3, exitErr.ExitCode())
+	b, err := os.ReadFile(out)
+	assert.Nil(err)
+	assert.Equal("pre pre fakefoo\npost fakefoo say hello 3\n", string(b))
+
+	// A failing pre-command hook aborts the command
+	setupHooks(t, fmt.Sprintf(`
+- command: fakefoo
+  pre: exit 2
+  post: echo post >> %s
+`, out))
+	err = cmd.RunE(cmd, []string{"say", "hello"})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "pre-command hook")
+	assert.False(errors.As(err, &exitErr))
+
+	// Invalid hooks are reported
+	setupHooks(t, "pre: echo pre\n")
+	err = cmd.RunE(cmd, []string{"say", "hello"})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "unable to read the command hooks")
+}
@@ -0,0 +1,9 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package cli
+
+// hookShell is the shell running the command hooks
+var hookShell = []string{"sh", "-c"}
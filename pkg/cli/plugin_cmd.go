@@ -5,6 +5,7 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -12,6 +13,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 )
 
 // GetCmdForPlugin returns a cobra command for the plugin.
@@ -20,9 +23,11 @@ func GetCmdForPlugin(p *PluginInfo) *cobra.Command {
 		Use:   p.Name,
 		Short: p.Description,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			runner := NewRunner(p.Name, p.InstallationPath, args)
-			ctx := context.Background()
-			return runner.Run(ctx)
+			return runWithHooks(p.Name, args, func() error {
+				runner := NewRunner(p.Name, p.InstallationPath, args)
+				ctx := context.Background()
+				return runner.Run(ctx)
+			})
 		},
 		DisableFlagParsing: true,
 		Annotations: map[string]string{
@@ -38,10 +43,29 @@ func GetCmdForPlugin(p *PluginInfo) *cobra.Command {
 		//   help	Help about any command
 		//   :4
 		//   Completion ended with directive: ShellCompDirectiveNoFileComp
+		// The sub-commands and flags are completed from the command tree
+		// captured at installation without running the plugin
+		if p.CommandTree != nil {
+			if completions, directive, found := p.CommandTree.complete(args, toComplete); found {
+				return completions, directive
+			}
+		}
+
 		completion := []string{"__complete"}
 		completion = append(completion, args...)
 		completion = append(completion, toComplete)
 
+		// The completions of a plugin are reused for a short time,
+		// as obtaining them often requires calling a server
+		ttl := getCompletionCacheTTL()
+		var key string
+		if ttl > 0 {
+			key = completionCacheKey(p, completion)
+			if entry, found := getCachedCompletion(p.Name, key); found {
+				return entry.Lines, entry.Directive
+			}
+		}
+
 		runner := NewRunner(p.Name, p.InstallationPath, completion)
 		ctx := context.Background()
 		output, _, err := runner.RunOutput(ctx)
@@ -65,7 +89,7 @@ func GetCmdForPlugin(p *PluginInfo) *cobra.Command {
 				}
 			}
 		}
-		return lines, directive
+		return cacheCompletion(p.Name, key, lines, directive, ttl), directive
 	}
 
 	cmd.SetHelpFunc(func(c *cobra.Command, args []string) {
@@ -79,6 +103,18 @@ func GetCmdForPlugin(p *PluginInfo) *cobra.Command {
 		// parsing ourselves and modify what gets passed along to the plugin.
 		helpArgs := getHelpArguments()
 
+		// The help captured at installation is used when available
+		if p.CommandTree != nil {
+			path := helpArgs[:len(helpArgs)-1]
+			if _, found := p.CommandTree.findCommand(path); found {
+				help, err := loadCommandHelp(p.InstallationPath)
+				if text := help[strings.Join(path, " ")]; err == nil && text != "" {
+					fmt.Fprint(c.OutOrStdout(), text)
+					return
+				}
+			}
+		}
+
 		// Pass this new command in to our plugin to have it handle help output
 		runner := NewRunner(p.Name, p.InstallationPath, helpArgs)
 		ctx := context.Background()
@@ -87,6 +123,14 @@ func GetCmdForPlugin(p *PluginInfo) *cobra.Command {
 			log.Errorf("Help output for '%s' is not available.", c.Name())
 		}
 	})
+
+	// The unmanaged plugins which do not describe themselves using the "info"
+	// command may not implement the completion command of the plugins
+	if p.Status == common.PluginStatusUnmanaged && p.Version == "" {
+		cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+	}
 	return cmd
 }
 
@@ -0,0 +1,85 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"os"
+	"strings"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// pluginEnvVariables are the environment variables set by the CLI for the plugins.
+// Any value inherited from the environment of the CLI is replaced.
+var pluginEnvVariables = []string{
+	constants.PluginEnvCLIVersion,
+	constants.PluginEnvConfigDir,
+	constants.PluginEnvContextK8s,
+	constants.PluginEnvContextTMC,
+	constants.PluginEnvKubeconfig,
+	constants.PluginEnvKubecontext,
+	constants.PluginEnvEndpointK8s,
+	constants.PluginEnvEndpointTMC,
+	constants.ContextOverride,
+}
+
+// pluginEnvironment returns the environment of a plugin invocation: the
+// environment of the CLI along with the information of the active contexts.
+func pluginEnvironment() []string {
+	values := map[string]string{
+		constants.PluginEnvCLIVersion: buildinfo.Version,
+		// Nested invocations of the CLI by the plugin use the same context
+		constants.ContextOverride: contextOverride,
+	}
+	if configDir, err := configlib.LocalDir(); err == nil {
+		values[constants.PluginEnvConfigDir] = configDir
+	}
+
+	currentContexts, err := GetAllCurrentContextsMap()
+	if err != nil {
+		log.V(6).Infof("unable to get the current contexts for the plugin environment: %v", err)
+	}
+	if ctx := currentContexts[configtypes.TargetK8s]; ctx != nil {
+		values[constants.PluginEnvContextK8s] = ctx.Name
+		if ctx.ClusterOpts != nil {
+			values[constants.PluginEnvKubeconfig] = ctx.ClusterOpts.Path
+			values[constants.PluginEnvKubecontext] = ctx.ClusterOpts.Context
+			values[constants.PluginEnvEndpointK8s] = ctx.ClusterOpts.Endpoint
+		}
+	}
+	if ctx := currentContexts[configtypes.TargetTMC]; ctx != nil {
+		values[constants.PluginEnvContextTMC] = ctx.Name
+		if ctx.GlobalOpts != nil {
+			values[constants.PluginEnvEndpointTMC] = ctx.GlobalOpts.Endpoint
+		}
+	}
+
+	env := make([]string, 0, len(os.Environ())+len(values))
+	for _, kv := range os.Environ() {
+		if !isPluginEnvVariable(kv) {
+			env = append(env, kv)
+		}
+	}
+	for _, name := range pluginEnvVariables {
+		if values[name] != "" {
+			env = append(env, name+"="+values[name])
+		}
+	}
+	return env
+}
+
+func isPluginEnvVariable(kv string) bool {
+	name := strings.SplitN(kv, "=", 2)[0]
+	for _, v := range pluginEnvVariables {
+		if name == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func setupTestConfig(t *testing.T) {
+	dir := t.TempDir()
+	for variable, file := range map[string]string{"TANZU_CONFIG": "config.yaml", "TANZU_CONFIG_NEXT_GEN": "config-ng.yaml"} {
+		path := filepath.Join(dir, file)
+		assert.Nil(t, os.WriteFile(path, []byte{}, 0o600))
+		t.Setenv(variable, path)
+	}
+}
+
+func TestPluginEnvironment(t *testing.T) {
+	assert := assert.New(t)
+	setupTestConfig(t)
+	defer func(version string) { buildinfo.Version = version }(buildinfo.Version)
+	buildinfo.Version = "v1.0.0"
+
+	// A value inherited from the environment of the CLI is never passed to the plugin
+	t.Setenv(constants.PluginEnvKubeconfig, "/inherited/kubeconfig")
+
+	runner := NewRunner("fakefoo", setupFakePluginScript(t, "fakefoo", "env | grep ^TANZU_CLI_ | sort"), nil)
+	stdout, _, err := runner.RunOutput(context.Background())
+	assert.Nil(err)
+	assert.Contains(stdout, constants.PluginEnvCLIVersion+"=v1.0.0\n")
+	assert.Contains(stdout, constants.PluginEnvConfigDir+"=")
+	assert.NotContains(stdout, constants.PluginEnvKubeconfig)
+	assert.NotContains(stdout, constants.PluginEnvContextK8s)
+
+	err = configlib.SetContext(&configtypes.Context{
+		Name:        "test-k8s",
+		Target:      configtypes.TargetK8s,
+		ClusterOpts: &configtypes.ClusterServer{Path: "/tmp/kubeconfig", Context: "admin@test", Endpoint: "https://test:6443"},
+	}, true)
+	assert.Nil(err)
+	err = configlib.SetContext(&configtypes.Context{
+		Name:       "test-tmc",
+		Target:     configtypes.TargetTMC,
+		GlobalOpts: &configtypes.GlobalServer{Endpoint: "test.tmc.example.com:443"},
+	}, true)
+	assert.Nil(err)
+
+	stdout, _, err = runner.RunOutput(context.Background())
+	assert.Nil(err)
+	env := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Contains(env, constants.PluginEnvContextK8s+"=test-k8s")
+	assert.Contains(env, constants.PluginEnvKubeconfig+"=/tmp/kubeconfig")
+	assert.Contains(env, constants.PluginEnvKubecontext+"=admin@test")
+	assert.Contains(env, constants.PluginEnvEndpointK8s+"=https://test:6443")
+	assert.Contains(env, constants.PluginEnvContextTMC+"=test-tmc")
+	assert.Contains(env, constants.PluginEnvEndpointTMC+"=test.tmc.example.com:443")
+	assert.NotContains(env, constants.PluginEnvKubeconfig+"=/inherited/kubeconfig")
+}
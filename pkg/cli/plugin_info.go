@@ -25,6 +25,9 @@ type PluginInfo struct {
 	// Digest is the SHA256 hash of the plugin binary.
 	Digest string `json:"digest" yaml:"digest"`
 
+	// TestDigest is the SHA256 hash of the test plugin binary, if installed.
+	TestDigest string `json:"testDigest,omitempty" yaml:"testDigest,omitempty"`
+
 	// Command group for the plugin.
 	Group plugin.CmdGroup `json:"group" yaml:"group"`
 
@@ -73,6 +76,10 @@ type PluginInfo struct {
 
 	// DefaultFeatureFlags is default featureflags to be configured if missing when invoking plugin
 	DefaultFeatureFlags map[string]bool `json:"defaultFeatureFlags" yaml:"defaultFeatureFlags"`
+
+	// CommandTree describes the commands of the plugin, captured when the plugin
+	// is installed, to provide help and completion without running the plugin.
+	CommandTree *CommandNode `json:"commandTree,omitempty" yaml:"commandTree,omitempty"`
 }
 
 // PluginInfoSorter sorts PluginInfo objects.
@@ -6,14 +6,54 @@ package cli
 import (
 	"bytes"
 	"context"
-
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+const (
+	// pluginTerminationGracePeriod is the time given to a plugin to exit after
+	// being asked to terminate, before it is killed
+	pluginTerminationGracePeriod = 10 * time.Second
+
+	// timeoutExitCode is the exit code of the CLI when a plugin times out,
+	// following the convention of the timeout command
+	timeoutExitCode = 124
 )
 
+// PluginExitError is returned when a plugin exits with a non-zero exit code
+// or is terminated by a signal. The CLI exits with the same exit code as the
+// plugin, or 128 plus the signal number if the plugin was terminated by a signal.
+type PluginExitError struct {
+	// Name is the name of the plugin
+	Name string
+	// Code is the exit code to use for the CLI
+	Code int
+	// Reason explains why the plugin was terminated by the CLI, if it was
+	Reason string
+}
+
+func (e *PluginExitError) Error() string {
+	if e.Reason != "" {
+		return e.Reason
+	}
+	return fmt.Sprintf("plugin %q exited with code %d", e.Name, e.Code)
+}
+
+// ExitCode returns the exit code to use for the CLI.
+func (e *PluginExitError) ExitCode() int {
+	return e.Code
+}
+
 // Runner is a plugin runner.
 type Runner struct {
 	name          string
@@ -75,8 +115,16 @@ func (r *Runner) run(ctx context.Context, pluginPath string, stdout, stderr *byt
 		return fmt.Errorf("%q is a directory", pluginPath)
 	}
 
-	cmd := exec.CommandContext(ctx, pluginPath, r.args...) //nolint:gosec
+	timeout := getPluginTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.Command(pluginPath, r.args...) //nolint:gosec
 
+	cmd.Env = pluginEnvironment()
 	cmd.Stdin = os.Stdin
 	// Check if the execution output should be captured
 	if stderr != nil {
@@ -90,8 +138,105 @@ func (r *Runner) run(ctx context.Context, pluginPath string, stdout, stderr *byt
 		cmd.Stdout = os.Stdout
 	}
 
-	err = cmd.Run()
-	return err
+	// A plugin attached to a terminal shares the process group of the CLI, so that it can
+	// read from the terminal and directly receives the interrupt signal when Ctrl-C is pressed.
+	// Otherwise the plugin runs in its own process group and the CLI forwards the signals it
+	// receives to the whole group, so that the processes started by the plugin also receive them.
+	ownProcessGroup := !IsTerminal(os.Stdin) && setOwnProcessGroup(cmd)
+
+	// The forwarded signals are handled for the whole execution of the plugin, so that the CLI
+	// keeps running until the plugin exits and can return the exit code of the plugin
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, forwardedSignals...)
+	defer signal.Stop(signals)
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	for {
+		select {
+		case err := <-done:
+			return r.exitError(err, cmd.ProcessState)
+		case sig := <-signals:
+			if sig == os.Interrupt && !ownProcessGroup {
+				// The plugin already received the interrupt signal from the terminal
+				continue
+			}
+			if err := signalPlugin(cmd.Process, sig, ownProcessGroup); err != nil {
+				log.V(6).Infof("unable to forward signal %v to plugin %q: %v", sig, r.name, err)
+			}
+		case <-ctx.Done():
+			err := r.terminate(cmd, done, ownProcessGroup)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && timeout > 0 {
+				return &PluginExitError{
+					Name:   r.name,
+					Code:   timeoutExitCode,
+					Reason: fmt.Sprintf("plugin %q timed out after %v, the timeout can be changed using the %s variable", r.name, timeout, constants.PluginTimeout),
+				}
+			}
+			return err
+		}
+	}
+}
+
+// terminate asks the plugin to terminate and kills it if it is still running
+// after the grace period. It returns the error of the plugin execution.
+func (r *Runner) terminate(cmd *exec.Cmd, done <-chan error, ownProcessGroup bool) error {
+	if err := signalPlugin(cmd.Process, terminationSignal, ownProcessGroup); err != nil {
+		log.V(6).Infof("unable to terminate plugin %q: %v", r.name, err)
+	}
+	select {
+	case err := <-done:
+		return r.exitError(err, cmd.ProcessState)
+	case <-time.After(pluginTerminationGracePeriod):
+		if err := signalPlugin(cmd.Process, os.Kill, ownProcessGroup); err != nil {
+			log.V(6).Infof("unable to kill plugin %q: %v", r.name, err)
+		}
+		return r.exitError(<-done, cmd.ProcessState)
+	}
+}
+
+// exitError converts the error of a plugin execution into a PluginExitError
+// if the plugin exited with a non-zero exit code or was terminated by a signal
+func (r *Runner) exitError(err error, state *os.ProcessState) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || state == nil {
+		return err
+	}
+	code := state.ExitCode()
+	if signum, signaled := terminatingSignal(state); signaled {
+		code = 128 + signum
+	}
+	return &PluginExitError{Name: r.name, Code: code}
+}
+
+// getPluginTimeout returns the maximum duration of a plugin command,
+// or zero if the plugin commands have no timeout
+func getPluginTimeout() time.Duration {
+	value := os.Getenv(constants.PluginTimeout)
+	if value == "" {
+		return 0
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		log.Warningf("invalid value %q for %s, the plugin command will not time out", value, constants.PluginTimeout)
+		return 0
+	}
+	return timeout
+}
+
+// IsTerminal returns true if the file is a terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
 }
 
 func (r *Runner) pluginPath() string {
@@ -0,0 +1,68 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func setupFakePluginScript(t *testing.T, pluginName, script string) string {
+	filePath := filepath.Join(t.TempDir(), pluginName)
+	err := os.WriteFile(filePath, []byte(fmt.Sprintf("#!/bin/bash\n\n%s\n", script)), 0o755)
+	assert.Nil(t, err)
+	return filePath
+}
+
+func TestRunnerExitCode(t *testing.T) {
+	assert := assert.New(t)
+
+	runner := NewRunner("fakefoo", setupFakePluginScript(t, "fakefoo", "echo hello"), nil)
+	stdout, _, err := runner.RunOutput(context.Background())
+	assert.Nil(err)
+	assert.Equal("hello\n", stdout)
+
+	// The exit code of the plugin is returned as is
+	runner = NewRunner("fakefoo", setupFakePluginScript(t, "fakefoo", "echo failure >&2; exit 3"), nil)
+	_, stderr, err := runner.RunOutput(context.Background())
+	assert.Equal("failure\n", stderr)
+	var exitErr *PluginExitError
+	assert.True(errors.As(err, &exitErr))
+	assert.Equal(3, exitErr.ExitCode())
+	assert.Empty(exitErr.Reason)
+
+	// A plugin terminated by a signal exits with 128 plus the signal number
+	runner = NewRunner("fakefoo", setupFakePluginScript(t, "fakefoo", "kill -TERM $$"), nil)
+	_, _, err = runner.RunOutput(context.Background())
+	assert.True(errors.As(err, &exitErr))
+	assert.Equal(128+15, exitErr.ExitCode())
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	runner := NewRunner("fakefoo", setupFakePluginScript(t, "fakefoo", "exec sleep 10"), nil)
+
+	t.Setenv(constants.PluginTimeout, "200ms")
+	start := time.Now()
+	_, _, err := runner.RunOutput(context.Background())
+	assert.Less(time.Since(start), 5*time.Second)
+	var exitErr *PluginExitError
+	assert.True(errors.As(err, &exitErr))
+	assert.Equal(timeoutExitCode, exitErr.ExitCode())
+	assert.Contains(exitErr.Error(), "timed out after 200ms")
+
+	// An invalid timeout is ignored
+	t.Setenv(constants.PluginTimeout, "invalid")
+	assert.Equal(time.Duration(0), getPluginTimeout())
+}
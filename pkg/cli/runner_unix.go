@@ -0,0 +1,43 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// forwardedSignals are the signals received by the CLI which are forwarded to a running plugin
+var forwardedSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}
+
+// terminationSignal is the signal sent to a plugin to ask it to terminate
+var terminationSignal os.Signal = syscall.SIGTERM
+
+// setOwnProcessGroup makes the plugin the leader of a new process group
+func setOwnProcessGroup(cmd *exec.Cmd) bool {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return true
+}
+
+// signalPlugin sends a signal to the plugin, or to the whole process group
+// of the plugin if the plugin runs in its own process group
+func signalPlugin(p *os.Process, sig os.Signal, processGroup bool) error {
+	s, ok := sig.(syscall.Signal)
+	if !processGroup || !ok {
+		return p.Signal(sig)
+	}
+	return syscall.Kill(-p.Pid, s)
+}
+
+// terminatingSignal returns the number of the signal which terminated the plugin, if any
+func terminatingSignal(state *os.ProcessState) (int, bool) {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return 0, false
+	}
+	return int(status.Signal()), true
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunnerForwardsSignals(t *testing.T) {
+	assert := assert.New(t)
+
+	script := "trap 'echo terminated; exit 7' TERM\nwhile true; do sleep 0.1; done"
+	runner := NewRunner("fakefoo", setupFakePluginScript(t, "fakefoo", script), nil)
+
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+	}()
+	stdout, _, err := runner.RunOutput(context.Background())
+	assert.Equal("terminated\n", stdout)
+	var exitErr *PluginExitError
+	assert.True(errors.As(err, &exitErr))
+	assert.Equal(7, exitErr.ExitCode())
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"os"
+	"os/exec"
+)
+
+// forwardedSignals are the signals received by the CLI while a plugin runs.
+// On Windows, Ctrl-C is delivered to every process attached to the console,
+// the CLI only needs to keep running until the plugin exits.
+var forwardedSignals = []os.Signal{os.Interrupt}
+
+// terminationSignal is the signal sent to a plugin to ask it to terminate.
+// Windows does not support sending an interrupt to another process.
+var terminationSignal = os.Kill
+
+// setOwnProcessGroup is not supported on Windows
+func setOwnProcessGroup(_ *exec.Cmd) bool {
+	return false
+}
+
+// signalPlugin sends a signal to the plugin
+func signalPlugin(p *os.Process, sig os.Signal, _ bool) error {
+	return p.Signal(sig)
+}
+
+// terminatingSignal always returns false on Windows where processes are not terminated by signals
+func terminatingSignal(_ *os.ProcessState) (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clientconfighelpers
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// The settings of the CLI which are not part of the configuration types of the
+// plugin runtime are stored in the CLI options of the client configuration, i.e.,
+// under clientOptions.cli, or in the entries of the discovery sources. The plugin
+// runtime updates the configuration node by node which preserves these settings.
+
+// cliOptionsKeys are the keys of the CLI options in the client configuration
+var cliOptionsKeys = []nodeutils.Key{
+	{Name: configlib.KeyClientOptions, Type: yaml.MappingNode},
+	{Name: configlib.KeyCLI, Type: yaml.MappingNode},
+}
+
+// cliOptionsConfigPath returns the path of the configuration file holding the
+// client options, which is config-ng.yaml when the unified configuration is used
+func cliOptionsConfigPath() (string, error) {
+	if unified, err := configlib.UseUnifiedConfig(); err == nil && unified {
+		return configlib.ClientConfigNextGenPath()
+	}
+	return configlib.ClientConfigPath()
+}
+
+func readConfigNode(path string) (*yaml.Node, error) {
+	b, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "unable to read the CLI configuration")
+	}
+	var node yaml.Node
+	if len(b) != 0 {
+		if err := yaml.Unmarshal(b, &node); err != nil {
+			return nil, errors.Wrap(err, "unable to parse the CLI configuration")
+		}
+	}
+	if node.Kind != yaml.DocumentNode || len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+		node = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	return &node, nil
+}
+
+// getCLIOptionsNode returns the node of the CLI options, or nil if there is none
+func getCLIOptionsNode() (*yaml.Node, error) {
+	path, err := cliOptionsConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	configlib.AcquireTanzuConfigLock()
+	defer configlib.ReleaseTanzuConfigLock()
+	node, err := readConfigNode(path)
+	if err != nil {
+		return nil, err
+	}
+	return nodeutils.FindNode(node.Content[0], nodeutils.WithKeys(cliOptionsKeys)), nil
+}
+
+// updateCLIOptionsNode applies the update to the node of the CLI options and
+// persists the configuration. The node is created if it does not exist.
+func updateCLIOptionsNode(update func(cliNode *yaml.Node) error) error {
+	path, err := cliOptionsConfigPath()
+	if err != nil {
+		return err
+	}
+	configlib.AcquireTanzuConfigLock()
+	defer configlib.ReleaseTanzuConfigLock()
+	node, err := readConfigNode(path)
+	if err != nil {
+		return err
+	}
+	cliNode := nodeutils.FindNode(node.Content[0], nodeutils.WithForceCreate(), nodeutils.WithKeys(cliOptionsKeys))
+	if cliNode == nil {
+		return nodeutils.ErrNodeNotFound
+	}
+	if err := update(cliNode); err != nil {
+		return err
+	}
+	b, err := yaml.Marshal(node)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the CLI configuration")
+	}
+	return utils.SaveFile(path, b)
+}
+
+// decodeMappingValue decodes the value of the key of the mapping node into value.
+// It returns false if the key does not exist.
+func decodeMappingValue(mappingNode *yaml.Node, key string, value interface{}) (bool, error) {
+	if mappingNode == nil {
+		return false, nil
+	}
+	idx := nodeutils.GetNodeIndex(mappingNode.Content, key)
+	if idx == -1 {
+		return false, nil
+	}
+	if err := mappingNode.Content[idx].Decode(value); err != nil {
+		return false, errors.Wrapf(err, "invalid value for %q in the CLI configuration", key)
+	}
+	return true, nil
+}
+
+// setMappingValue sets the key of the mapping node to the encoded value
+func setMappingValue(mappingNode *yaml.Node, key string, value interface{}) error {
+	valueNode := &yaml.Node{}
+	if err := valueNode.Encode(value); err != nil {
+		return errors.Wrapf(err, "unable to encode the value of %q", key)
+	}
+	if idx := nodeutils.GetNodeIndex(mappingNode.Content, key); idx != -1 {
+		mappingNode.Content[idx] = valueNode
+		return nil
+	}
+	mappingNode.Content = append(mappingNode.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, valueNode)
+	return nil
+}
+
+// deleteMappingValue removes the key of the mapping node
+func deleteMappingValue(mappingNode *yaml.Node, key string) {
+	if idx := nodeutils.GetNodeIndex(mappingNode.Content, key); idx != -1 {
+		mappingNode.Content = append(mappingNode.Content[:idx-1], mappingNode.Content[idx+1:]...)
+	}
+}
+
+// GetCLISetting decodes into value the CLI setting with the specified key.
+// It returns false if the setting is not configured.
+func GetCLISetting(key string, value interface{}) (bool, error) {
+	cliNode, err := getCLIOptionsNode()
+	if err != nil {
+		return false, err
+	}
+	return decodeMappingValue(cliNode, key, value)
+}
+
+// SetCLISetting stores the value as the CLI setting with the specified key
+func SetCLISetting(key string, value interface{}) error {
+	return updateCLIOptionsNode(func(cliNode *yaml.Node) error {
+		return setMappingValue(cliNode, key, value)
+	})
+}
+
+// DeleteCLISetting removes the CLI setting with the specified key
+func DeleteCLISetting(key string) error {
+	return updateCLIOptionsNode(func(cliNode *yaml.Node) error {
+		deleteMappingValue(cliNode, key)
+		return nil
+	})
+}
+
+// findDiscoverySourceNode returns the node holding the name of the discovery
+// source, e.g., the "oci" node of an OCI discovery source, or nil if the
+// discovery source is not configured
+func findDiscoverySourceNode(cliNode *yaml.Node, sourceName string) *yaml.Node {
+	if cliNode == nil {
+		return nil
+	}
+	sourcesNode := nodeutils.FindNode(cliNode, nodeutils.WithKeys([]nodeutils.Key{{Name: configlib.KeyDiscoverySources}}))
+	if sourcesNode == nil {
+		return nil
+	}
+	for _, sourceNode := range sourcesNode.Content {
+		// Each discovery source has a single key, its type
+		for i := 1; i < len(sourceNode.Content); i += 2 {
+			typeNode := sourceNode.Content[i]
+			if typeNode.Kind != yaml.MappingNode {
+				continue
+			}
+			if idx := nodeutils.GetNodeIndex(typeNode.Content, "name"); idx != -1 && typeNode.Content[idx].Value == sourceName {
+				return typeNode
+			}
+		}
+	}
+	return nil
+}
+
+// GetDiscoverySourceSetting decodes into value the setting with the specified key
+// of the discovery source. It returns false if the setting is not configured.
+func GetDiscoverySourceSetting(sourceName, key string, value interface{}) (bool, error) {
+	cliNode, err := getCLIOptionsNode()
+	if err != nil {
+		return false, err
+	}
+	return decodeMappingValue(findDiscoverySourceNode(cliNode, sourceName), key, value)
+}
+
+// SetDiscoverySourceSetting stores the value as the setting with the specified key
+// of the discovery source. The discovery source must be configured.
+func SetDiscoverySourceSetting(sourceName, key string, value interface{}) error {
+	return updateCLIOptionsNode(func(cliNode *yaml.Node) error {
+		sourceNode := findDiscoverySourceNode(cliNode, sourceName)
+		if sourceNode == nil {
+			return errors.Errorf("discovery source %q not found", sourceName)
+		}
+		return setMappingValue(sourceNode, key, value)
+	})
+}
+
+// DeleteDiscoverySourceSetting removes the setting with the specified key of the discovery source
+func DeleteDiscoverySourceSetting(sourceName, key string) error {
+	return updateCLIOptionsNode(func(cliNode *yaml.Node) error {
+		if sourceNode := findDiscoverySourceNode(cliNode, sourceName); sourceNode != nil {
+			deleteMappingValue(sourceNode, key)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clientconfighelpers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func setupCLIConfig(t *testing.T) string {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	t.Setenv("TANZU_CONFIG", configFile)
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+	return configFile
+}
+
+func TestCLISettings(t *testing.T) {
+	assert := assert.New(t)
+	setupCLIConfig(t)
+
+	var aliases map[string][]string
+	found, err := GetCLISetting("aliases", &aliases)
+	assert.Nil(err)
+	assert.False(found)
+
+	assert.Nil(SetCLISetting("aliases", map[string][]string{"wl": {"apps", "workload", "list", "--label", "a b"}}))
+	found, err = GetCLISetting("aliases", &aliases)
+	assert.Nil(err)
+	assert.True(found)
+	assert.Equal([]string{"apps", "workload", "list", "--label", "a b"}, aliases["wl"])
+
+	// The settings are kept when the plugin runtime updates the configuration
+	assert.Nil(configlib.SetEnv("FOO", "bar"))
+	aliases = nil
+	found, err = GetCLISetting("aliases", &aliases)
+	assert.Nil(err)
+	assert.True(found)
+	assert.Len(aliases, 1)
+
+	assert.Nil(DeleteCLISetting("aliases"))
+	found, err = GetCLISetting("aliases", &aliases)
+	assert.Nil(err)
+	assert.False(found)
+	env, err := configlib.GetEnv("FOO")
+	assert.Nil(err)
+	assert.Equal("bar", env)
+}
+
+func TestDiscoverySourceSettings(t *testing.T) {
+	assert := assert.New(t)
+	setupCLIConfig(t)
+
+	err := SetDiscoverySourceSetting("internal", "cacheTTL", "1h")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `discovery source "internal" not found`)
+
+	source := configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "internal", Image: "registry.example.com/plugins/plugin-inventory:latest"}}
+	assert.Nil(configlib.SetCLIDiscoverySource(source))
+	assert.Nil(SetDiscoverySourceSetting("internal", "cacheTTL", "1h"))
+
+	var ttl string
+	found, err := GetDiscoverySourceSetting("internal", "cacheTTL", &ttl)
+	assert.Nil(err)
+	assert.True(found)
+	assert.Equal("1h", ttl)
+
+	// Updating the discovery source keeps its settings
+	source.OCI.Image = "registry.example.com/plugins/plugin-inventory:v2"
+	assert.Nil(configlib.SetCLIDiscoverySource(source))
+	found, err = GetDiscoverySourceSetting("internal", "cacheTTL", &ttl)
+	assert.Nil(err)
+	assert.True(found)
+	sources, err := configlib.GetCLIDiscoverySources()
+	assert.Nil(err)
+	assert.Len(sources, 1)
+	assert.Equal(source.OCI.Image, sources[0].OCI.Image)
+
+	assert.Nil(DeleteDiscoverySourceSetting("internal", "cacheTTL"))
+	found, err = GetDiscoverySourceSetting("internal", "cacheTTL", &ttl)
+	assert.Nil(err)
+	assert.False(found)
+
+	// Deleting the discovery source deletes its settings
+	assert.Nil(SetDiscoverySourceSetting("internal", "cacheTTL", "2h"))
+	assert.Nil(configlib.DeleteCLIDiscoverySource("internal"))
+	assert.Nil(configlib.SetCLIDiscoverySource(source))
+	found, err = GetDiscoverySourceSetting("internal", "cacheTTL", &ttl)
+	assert.Nil(err)
+	assert.False(found)
+}
+
+func TestCLISettingsInvalidConfig(t *testing.T) {
+	assert := assert.New(t)
+	configFile := setupCLIConfig(t)
+
+	assert.Nil(os.WriteFile(configFile, []byte("clientOptions:\n  cli:\n    aliases: [\n"), 0o600))
+	var aliases map[string][]string
+	_, err := GetCLISetting("aliases", &aliases)
+	assert.NotNil(err)
+	assert.NotNil(SetCLISetting("aliases", map[string][]string{}))
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
+)
+
+func newAliasCmd() *cobra.Command {
+	var aliasCmd = &cobra.Command{
+		Use:   "alias",
+		Short: "Manage user-defined command aliases",
+		Long: `Manage user-defined command aliases.
+An alias is a shortcut for a command line of the CLI. When the first argument of the
+CLI is an alias, it is replaced by the command line of the alias and the remaining
+arguments are appended. The commands of the CLI and of the installed plugins take
+precedence over the aliases.`,
+		Annotations: map[string]string{
+			"group": string(plugin.SystemCmdGroup),
+		},
+	}
+	aliasCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	listAliasCmd := newListAliasCmd()
+	listAliasCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	aliasCmd.AddCommand(
+		newSetAliasCmd(),
+		listAliasCmd,
+		newDeleteAliasCmd(),
+	)
+	return aliasCmd
+}
+
+func newSetAliasCmd() *cobra.Command {
+	var setAliasCmd = &cobra.Command{
+		Use:   "set NAME COMMAND...",
+		Short: "Create or update an alias",
+		Long: `Create or update an alias. The command line of the alias does not include the
+tanzu prefix. The name of the alias cannot be the name of a command of the CLI or
+of an installed plugin.`,
+		Example: `
+    # Create an alias for the management-cluster command
+    tanzu alias set mc management-cluster
+
+    # Create an alias for a command with flags
+    tanzu alias set wl apps workload list --all-namespaces
+
+    # Arguments containing spaces are kept as is
+    tanzu alias set web apps workload list --label "app=my web app"`,
+		// The command line of the alias can contain flags
+		DisableFlagParsing: true,
+		ValidArgsFunction:  cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+				return cmd.Help()
+			}
+			if len(args) < 2 {
+				return errors.New("an alias name and a command are required")
+			}
+			name := args[0]
+			if isCommandName(cmd.Root(), name) {
+				return errors.Errorf("%q is already a command of the CLI or of an installed plugin", name)
+			}
+			if err := cliconfig.SetAlias(name, args[1:]); err != nil {
+				return err
+			}
+			log.Successf("alias %q set to %q", name, formatCommandLine(args[1:]))
+			return nil
+		},
+	}
+	return setAliasCmd
+}
+
+func newListAliasCmd() *cobra.Command {
+	var listAliasCmd = &cobra.Command{
+		Use:               "list",
+		Short:             "List the aliases",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			aliases, err := cliconfig.GetAliases()
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "Name", "Command")
+			for _, alias := range aliases {
+				output.AddRow(alias.Name, formatCommandLine(alias.Command))
+			}
+			output.Render()
+			return nil
+		},
+	}
+	return listAliasCmd
+}
+
+func newDeleteAliasCmd() *cobra.Command {
+	var deleteAliasCmd = &cobra.Command{
+		Use:   "delete NAME",
+		Short: "Delete an alias",
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return aliasCompletions(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cliconfig.DeleteAlias(args[0]); err != nil {
+				return err
+			}
+			log.Successf("alias %q deleted", args[0])
+			return nil
+		},
+	}
+	return deleteAliasCmd
+}
+
+// aliasCompletions returns the names of the aliases along with their command line as description
+func aliasCompletions() []string {
+	aliases, err := cliconfig.GetAliases()
+	if err != nil {
+		return nil
+	}
+	var completions []string
+	for _, alias := range aliases {
+		completions = append(completions, fmt.Sprintf("%s\tAlias for %q", alias.Name, formatCommandLine(alias.Command)))
+	}
+	return completions
+}
+
+// formatCommandLine returns the command line of an alias as it would be typed,
+// quoting the arguments which contain spaces or quotes
+func formatCommandLine(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"") {
+			arg = strconv.Quote(arg)
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// isCommandName returns true if the name is the name or an alias of a
+// command of the root command, including the commands added by cobra
+func isCommandName(rootCmd *cobra.Command, name string) bool {
+	if name == "help" || name == cobra.ShellCompRequestCmd || name == cobra.ShellCompNoDescRequestCmd {
+		return true
+	}
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// commandNameIndex returns the index of the argument naming the root level
+// command, also when completing the arguments following it, or -1 if the
+// command is not named
+func commandNameIndex(args []string) int {
+	i := 0
+	if len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd) {
+		i = 1
+		// The last argument is the one being completed
+		if len(args) < 3 {
+			return -1
+		}
+	}
+	if len(args) <= i {
+		return -1
+	}
+	return i
+}
+
+// expandAlias replaces a user-defined alias used as the first argument with the
+// command line of the alias. The alias is also expanded when completing the
+// arguments following it. The commands take precedence over the aliases.
+// The expanded arguments are returned, or nil if there is no alias to expand.
+func expandAlias(rootCmd *cobra.Command, args []string) []string {
+	i := commandNameIndex(args)
+	if i < 0 {
+		return nil
+	}
+
+	name := args[i]
+	command, exists, err := cliconfig.GetAlias(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning, unable to read the aliases: %v\n\n", err)
+		return nil
+	}
+	if !exists {
+		return nil
+	}
+	if isCommandName(rootCmd, name) {
+		fmt.Fprintf(os.Stderr, "Warning, the alias %s is ignored because a command with that name exists\n\n", name)
+		return nil
+	}
+
+	expanded := append([]string{}, args[:i]...)
+	expanded = append(expanded, command...)
+	return append(expanded, args[i+1:]...)
+}
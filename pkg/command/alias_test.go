@@ -0,0 +1,63 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
+)
+
+func TestExpandAlias(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+
+	rootCmd := &cobra.Command{Use: "tanzu"}
+	rootCmd.AddCommand(&cobra.Command{Use: "version"}, &cobra.Command{Use: "management-cluster", Aliases: []string{"mc"}})
+
+	assert.Nil(cliconfig.SetAlias("wl", []string{"apps", "workload", "list", "--all-namespaces"}))
+	assert.Nil(cliconfig.SetAlias("web", []string{"apps", "workload", "list", "--label", "app=my web app"}))
+	assert.Nil(cliconfig.SetAlias("mc", []string{"version"}))
+
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{args: nil},
+		{args: []string{"version"}},
+		{args: []string{"wl"}, expected: []string{"apps", "workload", "list", "--all-namespaces"}},
+		{args: []string{"wl", "-o", "json"}, expected: []string{"apps", "workload", "list", "--all-namespaces", "-o", "json"}},
+		{args: []string{"version", "wl"}},
+		// The arguments of the alias are not split
+		{args: []string{"web"}, expected: []string{"apps", "workload", "list", "--label", "app=my web app"}},
+		// The commands take precedence over the aliases
+		{args: []string{"mc"}},
+		// Completion of the arguments following an alias
+		{args: []string{cobra.ShellCompRequestCmd, "wl"}},
+		{args: []string{cobra.ShellCompRequestCmd, "wl", ""}, expected: []string{cobra.ShellCompRequestCmd, "apps", "workload", "list", "--all-namespaces", ""}},
+	}
+	for _, spec := range tests {
+		assert.Equal(spec.expected, expandAlias(rootCmd, spec.args), spec.args)
+	}
+
+	// An alias cannot be set with the name of a command
+	setCmd := newSetAliasCmd()
+	rootCmd.AddCommand(setCmd)
+	err := setCmd.RunE(setCmd, []string{"version", "plugin", "list"})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "already a command")
+	assert.Nil(setCmd.RunE(setCmd, []string{"pl", "plugin", "list", "--local", "/tmp/my plugins"}))
+	command, exists, err := cliconfig.GetAlias("pl")
+	assert.Nil(err)
+	assert.True(exists)
+	assert.Equal([]string{"plugin", "list", "--local", "/tmp/my plugins"}, command)
+	assert.Equal(`plugin list --local "/tmp/my plugins"`, formatCommandLine(command))
+}
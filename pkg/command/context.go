@@ -30,7 +30,6 @@ import (
 	tkgauth "github.com/vmware-tanzu/tanzu-cli/pkg/auth/tkg"
 	wcpauth "github.com/vmware-tanzu/tanzu-cli/pkg/auth/wcp"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
-	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
@@ -142,7 +141,7 @@ func createCtx(_ *cobra.Command, _ []string) (err error) {
 }
 
 func syncContextPlugins() {
-	if err := pluginmanager.SyncPlugins(); err != nil {
+	if err := syncPlugins(); err != nil {
 		log.Warning("unable to automatically sync the plugins from target context. Please run 'tanzu plugin sync' command to sync plugins manually")
 	}
 }
@@ -726,8 +725,8 @@ func useCtx(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Sync all required plugins
-	syncContextPlugins()
+	// Offer to sync the missing or outdated plugins, or sync them, based on the configuration
+	checkContextPluginsOnUse()
 
 	return nil
 }
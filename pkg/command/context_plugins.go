@@ -0,0 +1,201 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// Actions taken when plugins recommended by the active contexts are missing or outdated
+const (
+	contextPluginSyncPrompt = "prompt"
+	contextPluginSyncAuto   = "auto"
+	contextPluginSyncWarn   = "warn"
+	contextPluginSyncOff    = "off"
+)
+
+// contextPluginCheckFileName is the name of the file, stored beside the plugin catalog,
+// which holds the current contexts whose recommended plugins were last checked
+const contextPluginCheckFileName = "context_plugin_check.yaml"
+
+// The discovery of the plugins and the confirmation are variables to be replaced in the tests
+var (
+	contextPluginsToSync     = pluginmanager.GetContextPluginsToSync
+	syncPlugins              = pluginmanager.SyncPlugins
+	isInteractive            = func() bool { return cli.IsTerminal(os.Stdin) }
+	confirmContextPluginSync = component.AskForConfirmation
+)
+
+// commandsWithoutContextPluginCheck are the commands which do not check the plugins
+// of the active contexts after a context switch, as they either manage the contexts
+// and the plugins themselves or do not use the plugins
+var commandsWithoutContextPluginCheck = []string{
+	"context", "login", "plugin", "init", "completion", "version", "help",
+	cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd,
+}
+
+type contextPluginCheck struct {
+	Contexts map[string]string `yaml:"contexts"`
+}
+
+func getContextPluginCheckFilePath() string {
+	return filepath.Join(catalog.GetCatalogCacheDir(), contextPluginCheckFileName)
+}
+
+// getContextPluginSyncMode returns the configured action taken for the missing or outdated plugins
+func getContextPluginSyncMode() string {
+	mode := strings.ToLower(os.Getenv(constants.ContextPluginSync))
+	switch mode {
+	case "":
+		return contextPluginSyncPrompt
+	case contextPluginSyncPrompt, contextPluginSyncAuto, contextPluginSyncWarn, contextPluginSyncOff:
+		return mode
+	}
+	log.Warningf("invalid value %q for %s, using %q", mode, constants.ContextPluginSync, contextPluginSyncPrompt)
+	return contextPluginSyncPrompt
+}
+
+// currentContextNames returns the name of the current context of every target
+func currentContextNames() map[string]string {
+	currentContexts, err := configlib.GetAllCurrentContextsMap()
+	if err != nil {
+		return nil
+	}
+	names := map[string]string{}
+	for target, ctx := range currentContexts {
+		names[string(target)] = ctx.Name
+	}
+	return names
+}
+
+// readCheckedContexts returns the current contexts whose recommended plugins were last checked
+func readCheckedContexts() map[string]string {
+	b, err := os.ReadFile(getContextPluginCheckFilePath())
+	if err != nil {
+		return map[string]string{}
+	}
+	var check contextPluginCheck
+	if err := yaml.Unmarshal(b, &check); err != nil || check.Contexts == nil {
+		return map[string]string{}
+	}
+	return check.Contexts
+}
+
+// saveCheckedContexts records the current contexts whose recommended plugins were checked
+func saveCheckedContexts(contexts map[string]string) {
+	b, err := yaml.Marshal(&contextPluginCheck{Contexts: contexts})
+	if err == nil {
+		err = utils.SaveFile(getContextPluginCheckFilePath(), b)
+	}
+	if err != nil {
+		log.V(6).Infof("unable to record the checked contexts: %v", err)
+	}
+}
+
+// checkContextPluginsAfterSwitch checks the plugins recommended by the active contexts
+// for the first command run after the current contexts have changed. Without a terminal,
+// the missing or outdated plugins are only reported unless they are synced automatically.
+func checkContextPluginsAfterSwitch(args []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || os.Getenv(constants.ContextOverride) != "" {
+		return
+	}
+	for _, name := range commandsWithoutContextPluginCheck {
+		if args[0] == name {
+			return
+		}
+	}
+	mode := getContextPluginSyncMode()
+	if mode == contextPluginSyncOff {
+		return
+	}
+
+	contexts := currentContextNames()
+	if contexts == nil || reflect.DeepEqual(contexts, readCheckedContexts()) {
+		return
+	}
+	saveCheckedContexts(contexts)
+
+	if mode == contextPluginSyncPrompt && !isInteractive() {
+		mode = contextPluginSyncWarn
+	}
+	syncContextPluginsWithMode(mode)
+}
+
+// checkContextPluginsOnUse checks the plugins recommended by the active contexts once
+// a context is used. Without a terminal, the plugins are synced automatically.
+func checkContextPluginsOnUse() {
+	mode := getContextPluginSyncMode()
+	if mode == contextPluginSyncOff {
+		return
+	}
+	if mode == contextPluginSyncPrompt && !isInteractive() {
+		mode = contextPluginSyncAuto
+	}
+	if contexts := currentContextNames(); contexts != nil {
+		saveCheckedContexts(contexts)
+	}
+	syncContextPluginsWithMode(mode)
+}
+
+// syncContextPluginsWithMode syncs the plugins of the active contexts. In the prompt mode,
+// the missing or outdated plugins are only synced after a confirmation, and in the warn
+// mode they are only reported.
+func syncContextPluginsWithMode(mode string) {
+	if mode == contextPluginSyncAuto {
+		syncContextPlugins()
+		return
+	}
+
+	plugins, err := contextPluginsToSync()
+	if err != nil {
+		log.Warningf("unable to check the plugins of the active contexts: %v", err)
+		return
+	}
+	if len(plugins) == 0 {
+		return
+	}
+
+	var descriptions []string
+	for i := range plugins {
+		descriptions = append(descriptions, describeContextPlugin(&plugins[i]))
+	}
+	message := fmt.Sprintf("The following plugins recommended by the active contexts are missing or outdated:\n  %s\n", strings.Join(descriptions, "\n  "))
+
+	if mode == contextPluginSyncWarn {
+		log.Warningf("%sRun 'tanzu plugin sync' to install them.", message)
+		return
+	}
+	if err := confirmContextPluginSync(message + "Install them now?"); err != nil {
+		log.Info("Run 'tanzu plugin sync' to install them later.")
+		return
+	}
+	syncContextPlugins()
+}
+
+func describeContextPlugin(p *discovery.Discovered) string {
+	description := fmt.Sprintf("%s %s (%s) from context %q", p.Name, p.RecommendedVersion, p.Target, p.ContextName)
+	if p.Status == common.PluginStatusUpdateAvailable {
+		description += fmt.Sprintf(", %s installed", p.InstalledVersion)
+	}
+	return description
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/otiai10/copy"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+func TestCheckContextPluginsAfterSwitch(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "tanzu-cli-context-plugins")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	assert.Nil(copy.Copy(filepath.Join("..", "fakes", "config", "tanzu_config.yaml"), filepath.Join(dir, "config.yaml")))
+	assert.Nil(copy.Copy(filepath.Join("..", "fakes", "config", "tanzu_config_ng.yaml"), filepath.Join(dir, "config-ng.yaml")))
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+	t.Setenv(constants.ContextOverride, "")
+	originalCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = dir
+
+	originalToSync, originalSync, originalInteractive, originalConfirm := contextPluginsToSync, syncPlugins, isInteractive, confirmContextPluginSync
+	defer func() {
+		contextPluginsToSync, syncPlugins, isInteractive, confirmContextPluginSync = originalToSync, originalSync, originalInteractive, originalConfirm
+		common.DefaultCacheDir = originalCacheDir
+	}()
+
+	checks, syncs := 0, 0
+	contextPluginsToSync = func() ([]discovery.Discovered, error) {
+		checks++
+		return []discovery.Discovered{{Name: "cluster", RecommendedVersion: "v1.0.0", Target: configtypes.TargetK8s, ContextName: "test-mc", Status: common.PluginStatusNotInstalled}}, nil
+	}
+	syncPlugins = func() error {
+		syncs++
+		return nil
+	}
+	interactive := true
+	isInteractive = func() bool { return interactive }
+	confirmed := errors.New("aborted")
+	confirmContextPluginSync = func(message string) error { return confirmed }
+
+	// The plugins are checked once after the current contexts have changed
+	checkContextPluginsAfterSwitch([]string{"cluster", "list"})
+	assert.Equal(1, checks)
+	assert.Equal(0, syncs)
+	checkContextPluginsAfterSwitch([]string{"cluster", "list"})
+	assert.Equal(1, checks)
+
+	// The plugins are synced once confirmed
+	confirmed = nil
+	assert.Nil(os.Remove(filepath.Join(dir, contextPluginCheckFileName)))
+	checkContextPluginsAfterSwitch([]string{"cluster", "list"})
+	assert.Equal(2, checks)
+	assert.Equal(1, syncs)
+
+	// The commands managing the contexts or the plugins do not check the plugins
+	assert.Nil(os.Remove(filepath.Join(dir, contextPluginCheckFileName)))
+	for _, args := range [][]string{{"plugin", "sync"}, {"context", "use", "x"}, {"__complete", "c"}, {"--context", "x", "cluster"}, {}} {
+		checkContextPluginsAfterSwitch(args)
+	}
+	assert.Equal(2, checks)
+
+	// Without a terminal, the plugins are only reported
+	interactive = false
+	checkContextPluginsAfterSwitch([]string{"cluster", "list"})
+	assert.Equal(3, checks)
+	assert.Equal(1, syncs)
+
+	// The plugins are synced automatically without being checked first
+	assert.Nil(os.Remove(filepath.Join(dir, contextPluginCheckFileName)))
+	t.Setenv(constants.ContextPluginSync, "auto")
+	checkContextPluginsAfterSwitch([]string{"cluster", "list"})
+	assert.Equal(3, checks)
+	assert.Equal(2, syncs)
+
+	// The check can be disabled
+	assert.Nil(os.Remove(filepath.Join(dir, contextPluginCheckFileName)))
+	t.Setenv(constants.ContextPluginSync, "off")
+	checkContextPluginsAfterSwitch([]string{"cluster", "list"})
+	checkContextPluginsOnUse()
+	assert.Equal(3, checks)
+	assert.Equal(2, syncs)
+
+	// Using a context without a terminal syncs the plugins in the prompt mode
+	t.Setenv(constants.ContextPluginSync, "")
+	checkContextPluginsOnUse()
+	assert.Equal(3, checks)
+	assert.Equal(3, syncs)
+	// and records the checked contexts
+	checkContextPluginsAfterSwitch([]string{"cluster", "list"})
+	assert.Equal(3, checks)
+
+	t.Setenv(constants.ContextPluginSync, "warn")
+	interactive = true
+	checkContextPluginsOnUse()
+	assert.Equal(4, checks)
+	assert.Equal(3, syncs)
+}
@@ -201,7 +201,13 @@ clusterOpts:
 
 	Describe("tanzu context use", func() {
 		cmd := &cobra.Command{}
+		var cacheDir string
 		BeforeEach(func() {
+			// The plugins of the context are checked using a file beside the plugin catalog
+			cacheDir, err = os.MkdirTemp("", "cache")
+			Expect(err).To(BeNil())
+			os.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", cacheDir)
+
 			tkgConfigFile, err = os.CreateTemp("", "config")
 			Expect(err).To(BeNil())
 			err = copy.Copy(filepath.Join("..", "fakes", "config", "tanzu_config.yaml"), tkgConfigFile.Name())
@@ -219,8 +225,10 @@ clusterOpts:
 		AfterEach(func() {
 			os.Unsetenv("TANZU_CONFIG")
 			os.Unsetenv("TANZU_CONFIG_NEXT_GEN")
+			os.Unsetenv("TEST_CUSTOM_CATALOG_CACHE_DIR")
 			os.RemoveAll(tkgConfigFile.Name())
 			os.RemoveAll(tkgConfigFileNG.Name())
+			os.RemoveAll(cacheDir)
 			resetContextCommandFlags()
 			buf.Reset()
 		})
@@ -6,6 +6,7 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
@@ -19,11 +20,14 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
 var (
 	discoverySourceType, discoverySourceName, uri string
+	discoverySourceCacheTTL                       time.Duration
+	discoverySourceVerifyPluginSignatures         bool
 )
 
 func newDiscoverySourceCmd() *cobra.Command {
@@ -44,6 +48,8 @@ func newDiscoverySourceCmd() *cobra.Command {
 	addDiscoverySourceCmd.Flags().StringVarP(&discoverySourceName, "name", "n", "", "name of discovery source")
 	addDiscoverySourceCmd.Flags().StringVarP(&discoverySourceType, "type", "t", "", "type of discovery source")
 	addDiscoverySourceCmd.Flags().StringVarP(&uri, "uri", "u", "", "URI for discovery source. URI format might be different based on the type of discovery source")
+	addDiscoverySourceCmd.Flags().DurationVarP(&discoverySourceCacheTTL, "cache-ttl", "", 0, "duration during which the cached plugin inventory is used without checking for a newer one (e.g. 30m, 24h)")
+	addDiscoverySourceCmd.Flags().BoolVarP(&discoverySourceVerifyPluginSignatures, "verify-plugin-signatures", "", false, "verify the signature of every plugin binary image of the discovery source before installation")
 
 	// Not handling errors below because cobra handles the error when flag user doesn't provide these required flags
 	_ = cobra.MarkFlagRequired(addDiscoverySourceCmd.Flags(), "name")
@@ -52,6 +58,8 @@ func newDiscoverySourceCmd() *cobra.Command {
 
 	updateDiscoverySourceCmd.Flags().StringVarP(&discoverySourceType, "type", "t", "", "type of discovery source")
 	updateDiscoverySourceCmd.Flags().StringVarP(&uri, "uri", "u", "", "URI for discovery source. URI format might be different based on the type of discovery source")
+	updateDiscoverySourceCmd.Flags().DurationVarP(&discoverySourceCacheTTL, "cache-ttl", "", 0, "duration during which the cached plugin inventory is used without checking for a newer one (e.g. 30m, 24h)")
+	updateDiscoverySourceCmd.Flags().BoolVarP(&discoverySourceVerifyPluginSignatures, "verify-plugin-signatures", "", false, "verify the signature of every plugin binary image of the discovery source before installation")
 
 	listDiscoverySourceCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
 
@@ -60,6 +68,7 @@ func newDiscoverySourceCmd() *cobra.Command {
 		addDiscoverySourceCmd,
 		updateDiscoverySourceCmd,
 		deleteDiscoverySourceCmd,
+		newDiscoverySourceTrustCmd(),
 	)
 
 	return discoverySourceCmd
@@ -75,7 +84,7 @@ func newListDiscoverySourceCmd() *cobra.Command {
 				return err
 			}
 
-			output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "name", "type", "scope")
+			output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "name", "type", "scope", "cache age")
 
 			// Get standalone scoped discoveries
 			if cfg.ClientOptions != nil && cfg.ClientOptions.CLI != nil && cfg.ClientOptions.CLI.DiscoverySources != nil {
@@ -109,9 +118,27 @@ func newListDiscoverySourceCmd() *cobra.Command {
 func outputFromDiscoverySources(discoverySources []configtypes.PluginDiscovery, scope string, output component.OutputWriter) {
 	for _, ds := range discoverySources {
 		dsName, dsType := discoverySourceNameAndType(ds)
-		output.AddRow(dsName, dsType, scope)
+		output.AddRow(dsName, dsType, scope, discoverySourceCacheAge(ds))
 	}
 }
+
+// discoverySourceCacheAge returns the time elapsed since the cached inventory
+// of the discovery source was last refreshed, or "-" if it is not cached
+func discoverySourceCacheAge(ds configtypes.PluginDiscovery) string {
+	d, err := discovery.CreateDiscoveryFromV1alpha1(ds, nil)
+	if err != nil {
+		return "-"
+	}
+	cachedDiscovery, ok := d.(discovery.CachedDiscovery)
+	if !ok {
+		return "-"
+	}
+	age, err := cachedDiscovery.CacheAge()
+	if err != nil {
+		return "-"
+	}
+	return age.Round(time.Second).String()
+}
 func newAddDiscoverySourceCmd() *cobra.Command {
 	var addDiscoverySourceCmd = &cobra.Command{
 		Use:   "add",
@@ -126,30 +153,18 @@ func newAddDiscoverySourceCmd() *cobra.Command {
     tanzu plugin source add --name standalone-oci --type oci --uri projects.registry.vmware.com/tkg/tanzu-plugins/standalone:latest`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Acquire tanzu config lock
-			configlib.AcquireTanzuConfigLock()
-			defer configlib.ReleaseTanzuConfigLock()
-
-			cfg, err := configlib.GetClientConfigNoLock()
-			if err != nil {
+			if err := addDiscoverySourceConfig(); err != nil {
 				return err
 			}
-			if cfg.ClientOptions == nil {
-				cfg.ClientOptions = &configtypes.ClientOptions{}
-			}
-			if cfg.ClientOptions.CLI == nil {
-				cfg.ClientOptions.CLI = &configtypes.CLIOptions{}
-			}
-
-			discoverySources, err := addDiscoverySource(cfg.ClientOptions.CLI.DiscoverySources, discoverySourceName, discoverySourceType, uri)
-			if err != nil {
-				return err
+			if cmd.Flags().Changed("cache-ttl") {
+				if err := discovery.SetCacheTTL(discoverySourceName, discoverySourceCacheTTL); err != nil {
+					return err
+				}
 			}
-
-			cfg.ClientOptions.CLI.DiscoverySources = discoverySources
-			err = configlib.StoreClientConfig(cfg)
-			if err != nil {
-				return err
+			if cmd.Flags().Changed("verify-plugin-signatures") {
+				if err := discovery.SetVerifyPluginSignatures(discoverySourceName, discoverySourceVerifyPluginSignatures); err != nil {
+					return err
+				}
 			}
 			log.Successf("successfully added discovery source %s", discoverySourceName)
 			return nil
@@ -158,48 +173,84 @@ func newAddDiscoverySourceCmd() *cobra.Command {
 	return addDiscoverySourceCmd
 }
 
+func addDiscoverySourceConfig() error {
+	// Acquire tanzu config lock
+	configlib.AcquireTanzuConfigLock()
+	defer configlib.ReleaseTanzuConfigLock()
+
+	cfg, err := configlib.GetClientConfigNoLock()
+	if err != nil {
+		return err
+	}
+	if cfg.ClientOptions == nil {
+		cfg.ClientOptions = &configtypes.ClientOptions{}
+	}
+	if cfg.ClientOptions.CLI == nil {
+		cfg.ClientOptions.CLI = &configtypes.CLIOptions{}
+	}
+
+	discoverySources, err := addDiscoverySource(cfg.ClientOptions.CLI.DiscoverySources, discoverySourceName, discoverySourceType, uri)
+	if err != nil {
+		return err
+	}
+
+	cfg.ClientOptions.CLI.DiscoverySources = discoverySources
+	return configlib.StoreClientConfig(cfg)
+}
+
 func newUpdateDiscoverySourceCmd() *cobra.Command {
 	var updateDiscoverySourceCmd = &cobra.Command{
 		Use:   "update [name]",
 		Short: "Update a discovery source configuration",
-		Args:  cobra.ExactArgs(1),
+		Long: `Update a discovery source configuration.
+If no configuration flag is specified, the cached plugin inventory of the
+discovery source is refreshed regardless of its cache TTL.`,
+		Args: cobra.ExactArgs(1),
 		Example: `
     # Update a local discovery source. If URI is relative path, 
     # $HOME/.config/tanzu-plugins will be considered base path
     tanzu plugin source update standalone-local --type local --uri new/path/to/local/discovery
 
     # Update an OCI discovery source. URI should be an OCI image.
-    tanzu plugin source update standalone-oci --type oci --uri projects.registry.vmware.com/tkg/tanzu-plugins/standalone:v1.0`,
+    tanzu plugin source update standalone-oci --type oci --uri projects.registry.vmware.com/tkg/tanzu-plugins/standalone:v1.0
+
+    # Use the cached plugin inventory of a discovery source for one day before checking for a newer one
+    tanzu plugin source update standalone-oci --cache-ttl 24h
+
+    # Verify the signature of every plugin binary image of a discovery source before installation
+    tanzu plugin source update standalone-oci --verify-plugin-signatures
+
+    # Refresh the cached plugin inventory of a discovery source
+    tanzu plugin source update standalone-oci`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
 			discoveryName := args[0]
 
-			// Acquire tanzu config lock
-			configlib.AcquireTanzuConfigLock()
-			defer configlib.ReleaseTanzuConfigLock()
-
-			cfg, err := configlib.GetClientConfigNoLock()
-			if err != nil {
-				return err
+			configChanged := cmd.Flags().Changed("type") || cmd.Flags().Changed("uri")
+			ttlChanged := cmd.Flags().Changed("cache-ttl")
+			verifyChanged := cmd.Flags().Changed("verify-plugin-signatures")
+			if !configChanged && !ttlChanged && !verifyChanged {
+				if err := pluginmanager.RefreshDiscoverySourceCache(discoveryName); err != nil {
+					return err
+				}
+				log.Successf("refreshed the plugin inventory of discovery source %s", discoveryName)
+				return nil
 			}
 
-			discoveryNoExistError := fmt.Errorf("discovery %q does not exist", discoveryName)
-			if cfg.ClientOptions == nil {
-				return discoveryNoExistError
-			}
-			if cfg.ClientOptions.CLI == nil {
-				return discoveryNoExistError
+			if configChanged {
+				if err := updateDiscoverySourceConfig(discoveryName); err != nil {
+					return err
+				}
 			}
-
-			newDiscoverySources, err := updateDiscoverySources(cfg.ClientOptions.CLI.DiscoverySources, discoveryName, discoverySourceType, uri)
-			if err != nil {
-				return err
+			if ttlChanged {
+				if err := discovery.SetCacheTTL(discoveryName, discoverySourceCacheTTL); err != nil {
+					return err
+				}
 			}
-
-			cfg.ClientOptions.CLI.DiscoverySources = newDiscoverySources
-			err = configlib.StoreClientConfig(cfg)
-			if err != nil {
-				return err
+			if verifyChanged {
+				if err := discovery.SetVerifyPluginSignatures(discoveryName, discoverySourceVerifyPluginSignatures); err != nil {
+					return err
+				}
 			}
 			log.Successf("updated discovery source %s", discoveryName)
 			return nil
@@ -208,6 +259,33 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 	return updateDiscoverySourceCmd
 }
 
+func updateDiscoverySourceConfig(discoveryName string) error {
+	// Acquire tanzu config lock
+	configlib.AcquireTanzuConfigLock()
+	defer configlib.ReleaseTanzuConfigLock()
+
+	cfg, err := configlib.GetClientConfigNoLock()
+	if err != nil {
+		return err
+	}
+
+	discoveryNoExistError := fmt.Errorf("discovery %q does not exist", discoveryName)
+	if cfg.ClientOptions == nil {
+		return discoveryNoExistError
+	}
+	if cfg.ClientOptions.CLI == nil {
+		return discoveryNoExistError
+	}
+
+	newDiscoverySources, err := updateDiscoverySources(cfg.ClientOptions.CLI.DiscoverySources, discoveryName, discoverySourceType, uri)
+	if err != nil {
+		return err
+	}
+
+	cfg.ClientOptions.CLI.DiscoverySources = newDiscoverySources
+	return configlib.StoreClientConfig(cfg)
+}
+
 func newDeleteDiscoverySourceCmd() *cobra.Command {
 	var deleteDiscoverySourceCmd = &cobra.Command{
 		Use:   "delete [name]",
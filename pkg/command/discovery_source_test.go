@@ -4,11 +4,16 @@
 package command
 
 import (
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 )
 
 func Test_createDiscoverySource(t *testing.T) {
@@ -157,3 +162,37 @@ func Test_deleteDiscoverySource(t *testing.T) {
 	assert.Nil(err)
 	assert.Equal(0, len(updatedDiscoverySources))
 }
+
+func TestDiscoverySourceCacheTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+	t.Setenv(constants.PluginDiscoveryCacheTTL, "")
+	runSourceCmd := func(args ...string) error {
+		cmd := newDiscoverySourceCmd()
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	err := runSourceCmd("add", "--name", "internal", "--type", "oci", "--uri", "registry.example.com/plugins/plugin-inventory:latest", "--cache-ttl", "24h")
+	assert.Nil(err)
+	ttl, err := discovery.GetCacheTTL("internal")
+	assert.Nil(err)
+	assert.Equal(24*time.Hour, ttl)
+
+	// The TTL is kept when the image of the discovery source is updated
+	err = runSourceCmd("update", "internal", "--type", "oci", "--uri", "registry.example.com/plugins/plugin-inventory:v2")
+	assert.Nil(err)
+	ttl, err = discovery.GetCacheTTL("internal")
+	assert.Nil(err)
+	assert.Equal(24*time.Hour, ttl)
+
+	// The TTL is deleted along with the discovery source
+	err = runSourceCmd("delete", "internal")
+	assert.Nil(err)
+	ttl, err = discovery.GetCacheTTL("internal")
+	assert.Nil(err)
+	assert.Equal(time.Duration(0), ttl)
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+var trustPublicKeys []string
+
+func newDiscoverySourceTrustCmd() *cobra.Command {
+	var trustCmd = &cobra.Command{
+		Use:   "trust",
+		Short: "Manage the public keys trusted for discovery images",
+		Long: `Manage the public keys trusted to verify the signature of discovery source images.
+A trust policy maps a discovery image, or an image pattern where '*' matches any
+sequence of characters, to one or more public keys and is stored in the CLI
+configuration. A discovery image is trusted if its signature can be verified with
+any of the keys of the matching trust policies, which allows to rotate keys by
+trusting the old and the new key for the duration of the rotation.`,
+	}
+	trustCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	listTrustCmd := newListTrustCmd()
+	addTrustCmd := newAddTrustCmd()
+	deleteTrustCmd := newDeleteTrustCmd()
+
+	listTrustCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	addTrustCmd.Flags().StringSliceVarP(&trustPublicKeys, "public-key", "k", []string{}, "path of a public key to trust (can be specified multiple times)")
+	_ = cobra.MarkFlagRequired(addTrustCmd.Flags(), "public-key")
+	deleteTrustCmd.Flags().StringSliceVarP(&trustPublicKeys, "public-key", "k", []string{}, "path of a public key to stop trusting (can be specified multiple times). If not specified, the entire trust policy is deleted")
+
+	trustCmd.AddCommand(
+		listTrustCmd,
+		addTrustCmd,
+		deleteTrustCmd,
+	)
+	return trustCmd
+}
+
+func newListTrustCmd() *cobra.Command {
+	var listTrustCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the trust policies of discovery images",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			policies, err := discovery.GetTrustPolicies()
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "image pattern", "public keys")
+			for _, policy := range policies {
+				output.AddRow(policy.ImagePattern, strings.Join(policy.PublicKeys, ", "))
+			}
+			output.Render()
+			return nil
+		},
+	}
+	return listTrustCmd
+}
+
+func newAddTrustCmd() *cobra.Command {
+	var addTrustCmd = &cobra.Command{
+		Use:   "add [image-pattern]",
+		Short: "Trust public keys for the discovery images matching an image pattern",
+		Args:  cobra.ExactArgs(1),
+		Example: `
+    # Trust a public key for all the discovery images of a registry project
+    tanzu plugin source trust add "registry.example.com/org/*" --public-key /path/to/cosign.pub
+
+    # Trust a new key in addition to the current key during a key rotation
+    tanzu plugin source trust add "registry.example.com/org/*" --public-key /path/to/new-cosign.pub`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys, err := normalizePublicKeyRefs(trustPublicKeys)
+			if err != nil {
+				return err
+			}
+			if err := discovery.AddTrustPolicyPublicKeys(args[0], keys); err != nil {
+				return err
+			}
+			log.Successf("updated the trust policy for %s", args[0])
+			return nil
+		},
+	}
+	return addTrustCmd
+}
+
+func newDeleteTrustCmd() *cobra.Command {
+	var deleteTrustCmd = &cobra.Command{
+		Use:   "delete [image-pattern]",
+		Short: "Stop trusting public keys for the discovery images matching an image pattern",
+		Args:  cobra.ExactArgs(1),
+		Example: `
+    # Stop trusting the old key after a key rotation
+    tanzu plugin source trust delete "registry.example.com/org/*" --public-key /path/to/old-cosign.pub
+
+    # Delete the trust policy of an image pattern
+    tanzu plugin source trust delete "registry.example.com/org/*"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys, err := normalizePublicKeyRefs(trustPublicKeys)
+			if err != nil {
+				return err
+			}
+			if err := discovery.DeleteTrustPolicyPublicKeys(args[0], keys); err != nil {
+				return err
+			}
+			log.Successf("updated the trust policy for %s", args[0])
+			return nil
+		},
+	}
+	return deleteTrustCmd
+}
+
+// normalizePublicKeyRefs converts the public key file paths to absolute paths.
+// Key references which are not files (e.g., KMS URIs) are kept as is.
+func normalizePublicKeyRefs(keys []string) ([]string, error) {
+	var refs []string
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if strings.Contains(key, "://") || strings.HasPrefix(key, "pkcs11:") {
+			refs = append(refs, key)
+			continue
+		}
+		absPath, err := filepath.Abs(key)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(absPath); err != nil {
+			return nil, errors.Wrapf(err, "invalid public key %q", key)
+		}
+		refs = append(refs, absPath)
+	}
+	return refs, nil
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/template"
 
 	"github.com/pkg/errors"
@@ -99,6 +100,14 @@ func genREADME(plugins []cli.PluginInfo) error {
 func genMarkdownTreePlugins(plugins []cli.PluginInfo) error {
 	args := []string{"generate-docs", "--docs-dir", docsDir}
 	for idx := range plugins {
+		// The docs of the plugins whose command tree was captured at
+		// installation are generated without running the plugin
+		if pluginCmd, err := cli.GetDocCmdForPlugin(&plugins[idx]); err == nil {
+			if err := genMarkdownTreePluginCmd(pluginCmd); err != nil {
+				return err
+			}
+			continue
+		}
 		runner := cli.NewRunner(plugins[idx].Name, plugins[idx].InstallationPath, args)
 		ctx := context.Background()
 		if err := runner.Run(ctx); err != nil {
@@ -107,3 +116,25 @@ func genMarkdownTreePlugins(plugins []cli.PluginInfo) error {
 	}
 	return nil
 }
+
+// genMarkdownTreePluginCmd generates the docs of the commands of a plugin
+// the same way as the "generate-docs" command of the plugins
+func genMarkdownTreePluginCmd(pluginCmd *cobra.Command) error {
+	identity := func(s string) string {
+		if !strings.HasPrefix(s, "tanzu") {
+			return fmt.Sprintf("tanzu_%s", s)
+		}
+		return s
+	}
+	emptyStr := func(s string) string { return "" }
+
+	tanzuCmd := cobra.Command{
+		Use: "tanzu",
+	}
+	// Necessary to generate correct output
+	tanzuCmd.AddCommand(pluginCmd)
+	if err := doc.GenMarkdownTreeCustom(pluginCmd, docsDir, emptyStr, identity); err != nil {
+		return fmt.Errorf("error generating docs %q", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/doctor"
+)
+
+var doctorOutputFormat string
+
+// doctorReport is the structured output of the doctor command
+type doctorReport struct {
+	Status doctor.Status        `json:"status" yaml:"status"`
+	Checks []doctor.CheckResult `json:"checks" yaml:"checks"`
+}
+
+func newDoctorCmd() *cobra.Command {
+	var doctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose problems with the CLI installation",
+		Long: `Diagnose problems with the CLI installation.
+The following checks are run and report pass, warn or fail along with a remediation hint:
+  - the CLI configuration can be read
+  - the discovery sources are reachable and their signature can be verified
+  - the installed plugin binaries match the catalog
+  - the kubeconfig of the contexts can be used and the current contexts have not expired
+  - the custom CA certificate and the proxy settings are valid`,
+		Example: `
+    # Diagnose the CLI installation
+    tanzu doctor
+
+    # Output the diagnostics in JSON format
+    tanzu doctor -o json`,
+		Annotations: map[string]string{
+			"group": string(plugin.SystemCmdGroup),
+		},
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			results := doctor.RunChecks(doctor.DefaultChecks())
+			status := doctor.Summary(results)
+
+			displayDoctorResults(cmd.OutOrStdout(), results, status)
+
+			if status == doctor.StatusFail {
+				return fmt.Errorf("some checks failed, see the remediation hints above")
+			}
+			return nil
+		},
+	}
+
+	doctorCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+	doctorCmd.Flags().StringVarP(&doctorOutputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	return doctorCmd
+}
+
+func displayDoctorResults(out io.Writer, results []doctor.CheckResult, status doctor.Status) {
+	if doctorOutputFormat == string(component.JSONOutputType) || doctorOutputFormat == string(component.YAMLOutputType) {
+		report := doctorReport{Status: status, Checks: results}
+		if report.Checks == nil {
+			report.Checks = []doctor.CheckResult{}
+		}
+		component.NewObjectWriter(out, doctorOutputFormat, report).Render()
+		return
+	}
+
+	output := component.NewOutputWriter(out, doctorOutputFormat, "Check", "Item", "Status", "Message", "Remediation")
+	for i := range results {
+		r := &results[i]
+		output.AddRow(r.Check, r.Item, string(r.Status), r.Message, r.Remediation)
+	}
+	output.Render()
+}
@@ -6,6 +6,7 @@ package command
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
@@ -36,6 +38,7 @@ var (
 	outputFormat string
 	targetStr    string
 	group        string
+	offline      bool
 )
 
 func newPluginCmd() *cobra.Command {
@@ -45,9 +48,24 @@ func newPluginCmd() *cobra.Command {
 		Annotations: map[string]string{
 			"group": string(plugin.SystemCmdGroup),
 		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if offline {
+				// The discovery reads the offline mode from the environment so that
+				// it applies to every plugin operation of this command
+				if err := os.Setenv(constants.PluginDiscoveryOffline, "true"); err != nil {
+					return err
+				}
+			}
+			// Cobra only runs the closest persistent pre-run, so run the root one explicitly
+			if root := cmd.Root(); root != cmd && root.PersistentPreRunE != nil {
+				return root.PersistentPreRunE(cmd, args)
+			}
+			return nil
+		},
 	}
 
 	pluginCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+	pluginCmd.PersistentFlags().BoolVar(&offline, "offline", false, "use the cached plugin inventories without contacting the discovery sources")
 
 	listPluginCmd := newListPluginCmd()
 	installPluginCmd := newInstallPluginCmd()
@@ -101,6 +119,13 @@ func newPluginCmd() *cobra.Command {
 		cleanPluginCmd,
 		syncPluginCmd,
 		discoverySourceCmd,
+		newVerifyPluginCmd(),
+		newRepairPluginCmd(),
+		newUsePluginCmd(),
+		newHistoryPluginCmd(),
+		newRollbackPluginCmd(),
+		newExecPluginCmd(),
+		newPrecedencePluginCmd(),
 	)
 
 	if !config.IsFeatureActivated(constants.FeatureDisableCentralRepositoryForTesting) {
@@ -111,7 +136,9 @@ func newPluginCmd() *cobra.Command {
 		}
 		pluginCmd.AddCommand(
 			newSearchPluginCmd(),
-			newPluginGroupCmd())
+			newPluginGroupCmd(),
+			newDownloadBundlePluginCmd(),
+			newUploadBundlePluginCmd())
 	}
 
 	return pluginCmd
@@ -133,6 +160,10 @@ func newListPluginCmd() *cobra.Command {
 					return err
 				}
 				sort.Sort(cli.PluginInfoSorter(standalonePlugins))
+				// The executables found on PATH are listed after the installed plugins
+				unmanagedPlugins := pluginsupplier.GetUnmanagedPlugins()
+				sort.Sort(cli.PluginInfoSorter(unmanagedPlugins))
+				standalonePlugins = append(standalonePlugins, unmanagedPlugins...)
 
 				// List installed context plugins and also missing context plugins.
 				// Showing missing ones guides the user to know some plugins are recommended for the
@@ -185,6 +216,13 @@ func newListPluginCmd() *cobra.Command {
 	return listCmd
 }
 
+// pluginDescription is the output of the plugin describe command
+type pluginDescription struct {
+	cli.PluginInfo `yaml:",inline"`
+	// InstalledVersions are the versions of the plugin installed side by side
+	InstalledVersions []string `yaml:"installedVersions,omitempty"`
+}
+
 func newDescribePluginCmd() *cobra.Command {
 	var describeCmd = &cobra.Command{
 		Use:   "describe [name]",
@@ -204,7 +242,18 @@ func newDescribePluginCmd() *cobra.Command {
 				return err
 			}
 
-			b, err := yaml.Marshal(pd)
+			description := pluginDescription{PluginInfo: *pd}
+			// The command tree is only used to provide help and completions
+			description.CommandTree = nil
+			if installed, err := pluginmanager.GetInstalledPluginVersions(pd.Name, pd.Target); err == nil {
+				for i := range installed {
+					if !utils.ContainsString(description.InstalledVersions, installed[i].Version) {
+						description.InstalledVersions = append(description.InstalledVersions, installed[i].Version)
+					}
+				}
+			}
+
+			b, err := yaml.Marshal(description)
 			if err != nil {
 				return errors.Wrap(err, "could not marshal plugin")
 			}
@@ -579,7 +628,7 @@ func displayInstalledAndMissingSplitView(installedStandalonePlugins []cli.Plugin
 			installedStandalonePlugins[index].Description,
 			string(installedStandalonePlugins[index].Target),
 			installedStandalonePlugins[index].Version,
-			common.PluginStatusInstalled,
+			standalonePluginStatus(&installedStandalonePlugins[index]),
 		)
 	}
 	outputStandalone.Render()
@@ -633,7 +682,7 @@ func displayInstalledAndMissingListView(installedStandalonePlugins []cli.PluginI
 			installedStandalonePlugins[index].Description,
 			string(installedStandalonePlugins[index].Target),
 			installedStandalonePlugins[index].Version,
-			common.PluginStatusInstalled,
+			standalonePluginStatus(&installedStandalonePlugins[index]),
 			"", // No context
 		)
 	}
@@ -664,6 +713,14 @@ func displayInstalledAndMissingListView(installedStandalonePlugins []cli.PluginI
 	outputWriter.Render()
 }
 
+// standalonePluginStatus returns the status shown for an installed stand-alone plugin
+func standalonePluginStatus(p *cli.PluginInfo) string {
+	if p.Status == common.PluginStatusUnmanaged {
+		return common.PluginStatusUnmanaged
+	}
+	return common.PluginStatusInstalled
+}
+
 func getTarget() configtypes.Target {
 	return configtypes.StringToTarget(strings.ToLower(targetStr))
 }
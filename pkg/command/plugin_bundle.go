@@ -0,0 +1,108 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/airgapped"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+type downloadPluginBundleOptions struct {
+	pluginDiscoveryImage string
+	tarFile              string
+	groups               []string
+	plugins              []string
+}
+
+type uploadPluginBundleOptions struct {
+	sourceTar string
+	destRepo  string
+}
+
+var dpbo downloadPluginBundleOptions
+var upbo uploadPluginBundleOptions
+
+func newDownloadBundlePluginCmd() *cobra.Command {
+	var downloadBundleCmd = &cobra.Command{
+		Use:   "download-bundle",
+		Short: "Download plugin bundle to the local system",
+		Long: `Download a plugin bundle to the local system. The bundle contains the plugin inventory
+and the images of the selected plugins and can be uploaded to an internal registry
+using the 'tanzu plugin upload-bundle' command in internet-restricted environments.`,
+		Example: `
+    # Download all plugins of the central repository
+    tanzu plugin download-bundle --to-tar /tmp/plugin_bundle_complete.tar.gz
+
+    # Download the plugins of specific plugin groups
+    tanzu plugin download-bundle --group vmware-tkg/default --to-tar /tmp/plugin_bundle_tkg.tar.gz
+
+    # Download all versions of specific plugins
+    tanzu plugin download-bundle --plugin cluster --plugin package --to-tar /tmp/plugin_bundle.tar.gz`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options := airgapped.DownloadPluginBundleOptions{
+				PluginInventoryImage: dpbo.pluginDiscoveryImage,
+				ToTar:                dpbo.tarFile,
+				Groups:               dpbo.groups,
+				Plugins:              dpbo.plugins,
+				ImageProcessor:       carvelhelpers.NewImageOperationsImpl(),
+			}
+			return options.DownloadPluginBundle()
+		},
+	}
+
+	f := downloadBundleCmd.Flags()
+	f.StringVarP(&dpbo.pluginDiscoveryImage, "image", "", constants.TanzuCLIDefaultCentralPluginDiscoveryImage, "URI of the plugin discovery image providing the plugins")
+	f.StringVarP(&dpbo.tarFile, "to-tar", "", "", "local tar file path to store the plugin images")
+	f.StringSliceVarP(&dpbo.groups, "group", "", []string{}, "only download the plugins specified in the plugin group(s) (can be specified multiple times)")
+	f.StringSliceVarP(&dpbo.plugins, "plugin", "", []string{}, "only download all versions of the specified plugin(s) (can be specified multiple times)")
+
+	_ = downloadBundleCmd.MarkFlagRequired("to-tar")
+
+	return downloadBundleCmd
+}
+
+func newUploadBundlePluginCmd() *cobra.Command {
+	var uploadBundleCmd = &cobra.Command{
+		Use:   "upload-bundle",
+		Short: "Upload plugin bundle to a repository",
+		Long: `Upload a plugin bundle created with 'tanzu plugin download-bundle' to a repository.
+The plugin inventory is published alongside the plugin images so that the
+uploaded plugin inventory image can be configured as a discovery source.`,
+		Example: `
+    # Upload the plugin bundle to the remote repository
+    tanzu plugin upload-bundle --tar /tmp/plugin_bundle_complete.tar.gz --to-repo registry.example.com/tanzu-cli/plugins`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options := airgapped.UploadPluginBundleOptions{
+				Tar:             upbo.sourceTar,
+				DestinationRepo: upbo.destRepo,
+				ImageProcessor:  carvelhelpers.NewImageOperationsImpl(),
+			}
+			inventoryImage, err := options.UploadPluginBundle()
+			if err != nil {
+				return err
+			}
+			log.Successf("successfully uploaded the plugin bundle. Use the following command to configure the discovery source:")
+			log.Infof("  tanzu plugin source add --name <name> --type oci --uri %s", inventoryImage)
+			return nil
+		},
+	}
+
+	f := uploadBundleCmd.Flags()
+	f.StringVarP(&upbo.sourceTar, "tar", "", "", "source tar file")
+	f.StringVarP(&upbo.destRepo, "to-repo", "", "", "destination repository for publishing plugins")
+
+	_ = uploadBundleCmd.MarkFlagRequired("tar")
+	_ = uploadBundleCmd.MarkFlagRequired("to-repo")
+
+	return uploadBundleCmd
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+)
+
+func newExecPluginCmd() *cobra.Command {
+	var execCmd = &cobra.Command{
+		Use:   "exec NAME [--target TARGET] [ARGS...]",
+		Short: "Run an installed plugin, including a plugin masked by another command",
+		Long: `Run an installed plugin, including a plugin whose command is masked by a core
+command or by another plugin with the same name. The --target flag selects the plugin
+when plugins with that name are installed for several targets. It must be specified
+before or immediately after the plugin name, the other arguments are passed to the plugin.`,
+		Example: `
+    # Run the cluster plugin for the kubernetes target
+    tanzu plugin exec cluster --target k8s list
+
+    # Run the login plugin masked by the login command of the CLI
+    tanzu plugin exec login --help`,
+		// The arguments of the plugin can contain flags
+		DisableFlagParsing: true,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return installedPluginNames(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+				return cmd.Help()
+			}
+			pluginName, target, pluginArgs, err := parseExecArgs(args)
+			if err != nil {
+				return err
+			}
+			p, err := findInstalledPlugin(pluginName, target)
+			if err != nil {
+				return err
+			}
+			return cli.GetCmdForPlugin(p).RunE(cmd, pluginArgs)
+		},
+	}
+	return execCmd
+}
+
+// parseExecArgs returns the plugin name, the target and the arguments of the plugin.
+// The --target flag can be specified before or immediately after the plugin name.
+func parseExecArgs(args []string) (string, string, []string, error) {
+	var pluginName, target string
+	for len(args) > 0 {
+		switch {
+		case args[0] == "--target" || args[0] == "-t":
+			if len(args) < 2 {
+				return "", "", nil, errors.Errorf("flag needs an argument: %s", args[0])
+			}
+			target = args[1]
+			args = args[2:]
+			continue
+		case strings.HasPrefix(args[0], "--target="):
+			target = strings.TrimPrefix(args[0], "--target=")
+			args = args[1:]
+			continue
+		case pluginName == "":
+			pluginName = args[0]
+			args = args[1:]
+			continue
+		}
+		break
+	}
+	if pluginName == "" {
+		return "", "", nil, errors.New("a plugin name is required")
+	}
+	if target != "" && !configtypes.IsValidTarget(target, true, true) {
+		return "", "", nil, errors.New("invalid target specified. Please specify correct value of `--target` or `-t` flag from 'global/kubernetes/k8s/mission-control/tmc'")
+	}
+	return pluginName, target, args, nil
+}
+
+// findInstalledPlugin returns the installed plugin with a name for a target. If the target
+// is not specified, the plugin must be installed for a single target. The executables found
+// on PATH are only used if no installed plugin matches.
+func findInstalledPlugin(pluginName, target string) (*cli.PluginInfo, error) {
+	plugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+	matches := matchingPlugins(plugins, pluginName, target)
+	if len(matches) == 0 {
+		if p := pluginsupplier.GetUnmanagedPlugin(pluginName); p != nil {
+			matches = matchingPlugins([]cli.PluginInfo{*p}, pluginName, target)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if target != "" {
+			return nil, errors.Errorf("plugin %q is not installed for target %q", pluginName, target)
+		}
+		return nil, errors.Errorf("plugin %q is not installed", pluginName)
+	case 1:
+		return matches[0], nil
+	}
+	var targets []string
+	for _, p := range matches {
+		targets = append(targets, string(p.Target))
+	}
+	return nil, errors.Errorf("plugin %q is installed for several targets (%s), specify the target with --target", pluginName, strings.Join(targets, ", "))
+}
+
+// matchingPlugins returns the plugins with a name, and with a target if specified
+func matchingPlugins(plugins []cli.PluginInfo, pluginName, target string) []*cli.PluginInfo {
+	var matches []*cli.PluginInfo
+	for i := range plugins {
+		if plugins[i].Name == pluginName && (target == "" || plugins[i].Target == configtypes.StringToTarget(target)) {
+			matches = append(matches, &plugins[i])
+		}
+	}
+	return matches
+}
+
+// installedPluginNames returns the names of the installed plugins along with their description
+func installedPluginNames() []string {
+	plugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil
+	}
+	plugins = append(plugins, pluginsupplier.GetUnmanagedPlugins()...)
+	var names []string
+	for i := range plugins {
+		names = append(names, fmt.Sprintf("%s\t%s", plugins[i].Name, plugins[i].Description))
+	}
+	return names
+}
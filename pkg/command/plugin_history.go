@@ -0,0 +1,106 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+)
+
+func newHistoryPluginCmd() *cobra.Command {
+	var historyCmd = &cobra.Command{
+		Use:   "history [NAME]",
+		Short: "Show the history of the plugin operations",
+		Long: `Show the history of the install, upgrade, sync, delete, use and rollback
+operations performed on the plugins, from the oldest to the most recent one.`,
+		Example: `
+    # Show the history of all the plugin operations
+    tanzu plugin history
+
+    # Show the history of the operations on the cluster plugin
+    tanzu plugin history cluster`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var pluginName string
+			if len(args) == 1 {
+				pluginName = args[0]
+			}
+			if !configtypes.IsValidTarget(targetStr, true, true) {
+				return errors.New("invalid target specified. Please specify correct value of `--target` or `-t` flag from 'global/kubernetes/k8s/mission-control/tmc'")
+			}
+
+			entries, err := pluginmanager.GetPluginHistory(pluginName, getTarget())
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "Timestamp", "Operation", "Name", "Target", "Context", "Old Version", "New Version", "Source", "Digest")
+			for i := range entries {
+				e := &entries[i]
+				if outputFormat == string(component.JSONOutputType) || outputFormat == string(component.YAMLOutputType) {
+					output.AddRow(e.Timestamp, e.Operation, e.Name, string(e.Target), e.ContextName, e.OldVersion, e.NewVersion, e.Source, e.Digest)
+				} else {
+					output.AddRow(e.Timestamp.Local().Format(time.RFC3339), e.Operation, e.Name, string(e.Target), e.ContextName, e.OldVersion, e.NewVersion, e.Source, shortDigest(e.Digest))
+				}
+			}
+			output.Render()
+			return nil
+		},
+	}
+
+	historyCmd.Flags().StringVarP(&targetStr, "target", "t", "", "target of the plugin (kubernetes[k8s]/mission-control[tmc])")
+	historyCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	return historyCmd
+}
+
+func newRollbackPluginCmd() *cobra.Command {
+	var rollbackCmd = &cobra.Command{
+		Use:   "rollback NAME",
+		Short: "Restore the previous version of a plugin",
+		Long: `Restore the version of a stand-alone plugin which was active before the
+last operation recorded for the plugin in the history, e.g., after an upgrade.
+The previous version is restored from the plugin directory without downloading it
+again. Rolling back twice restores the version active before the first rollback.`,
+		Example: `
+    # Restore the previous version of the cluster plugin
+    tanzu plugin rollback cluster`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !configtypes.IsValidTarget(targetStr, true, true) {
+				return errors.New("invalid target specified. Please specify correct value of `--target` or `-t` flag from 'global/kubernetes/k8s/mission-control/tmc'")
+			}
+
+			entry, err := pluginmanager.RollbackPlugin(args[0], getTarget())
+			if err != nil {
+				return err
+			}
+			log.Successf("rolled back the %s of plugin '%s', now using version %s", entry.Operation, args[0], entry.OldVersion)
+			return nil
+		},
+	}
+
+	rollbackCmd.Flags().StringVarP(&targetStr, "target", "t", "", "target of the plugin (kubernetes[k8s]/mission-control[tmc])")
+
+	return rollbackCmd
+}
+
+// shortDigest returns the beginning of a digest for display
+func shortDigest(digest string) string {
+	const shortDigestLength = 12
+	if len(digest) > shortDigestLength {
+		return digest[:shortDigestLength]
+	}
+	return digest
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
+)
+
+func newPrecedencePluginCmd() *cobra.Command {
+	var precedenceCmd = &cobra.Command{
+		Use:   "precedence",
+		Short: "Select the command to run when several commands have the same name",
+		Long: `Select the command to run when a core command and plugins, or plugins for several
+targets, provide a root level command with the same name. By default, a plugin for the
+current context takes precedence over a stand-alone plugin, and the other plugins are
+masked with a warning. Once the command precedence is set, the selected command is run
+and the warning is no longer shown. The masked plugins can be run using 'tanzu plugin exec'.`,
+	}
+	precedenceCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	listPrecedenceCmd := newListPrecedenceCmd()
+	listPrecedenceCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	precedenceCmd.AddCommand(
+		newSetPrecedenceCmd(),
+		listPrecedenceCmd,
+		newDeletePrecedenceCmd(),
+	)
+	return precedenceCmd
+}
+
+func newSetPrecedenceCmd() *cobra.Command {
+	var setPrecedenceCmd = &cobra.Command{
+		Use:   "set COMMAND core|global|kubernetes",
+		Short: "Select the command to run for a command name",
+		Long: `Select the command to run for a command name: the core command of the CLI,
+or the plugin for the global or the kubernetes target.`,
+		Example: `
+    # Run the core login command rather than the login plugin
+    tanzu plugin precedence set login core
+
+    # Run the cluster plugin for the kubernetes target rather than the global one
+    tanzu plugin precedence set cluster kubernetes`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return []string{cliconfig.CommandPrecedenceCore, string(configtypes.TargetGlobal), string(configtypes.TargetK8s)}, cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if args[0] == "plugin" && args[1] != cliconfig.CommandPrecedenceCore {
+				return errors.New("the plugin command of the CLI cannot be replaced")
+			}
+			if err := cliconfig.SetCommandPrecedence(args[0], args[1]); err != nil {
+				return err
+			}
+			log.Successf("command precedence of %q set to %q", args[0], args[1])
+			return nil
+		},
+	}
+	return setPrecedenceCmd
+}
+
+func newListPrecedenceCmd() *cobra.Command {
+	var listPrecedenceCmd = &cobra.Command{
+		Use:               "list",
+		Short:             "List the command precedence",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			precedences, err := cliconfig.GetCommandPrecedences()
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "Command", "Winner")
+			for _, p := range precedences {
+				output.AddRow(p.Command, p.Winner)
+			}
+			output.Render()
+			return nil
+		},
+	}
+	return listPrecedenceCmd
+}
+
+func newDeletePrecedenceCmd() *cobra.Command {
+	var deletePrecedenceCmd = &cobra.Command{
+		Use:               "delete COMMAND",
+		Short:             "Restore the default precedence for a command name",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cliconfig.DeleteCommandPrecedence(args[0]); err != nil {
+				return err
+			}
+			log.Successf("command precedence of %q deleted", args[0])
+			return nil
+		},
+	}
+	return deletePrecedenceCmd
+}
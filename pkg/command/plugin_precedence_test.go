@@ -0,0 +1,197 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// runRootCmd runs the root command with the arguments and returns its output
+func runRootCmd(t *testing.T, args ...string) (string, error) {
+	r, w, err := os.Pipe()
+	assert.Nil(t, err)
+	c := make(chan []byte)
+	go readOutput(t, r, c)
+
+	stdout := os.Stdout
+	stderr := os.Stderr
+	osArgs := os.Args
+	defer func() {
+		os.Stdout = stdout
+		os.Stderr = stderr
+		os.Args = osArgs
+	}()
+	os.Stdout = w
+	os.Stderr = w
+	// The root command is created for the arguments of the CLI
+	os.Args = append([]string{"tanzu"}, args...)
+
+	rootCmd, err := NewRootCmd()
+	if err == nil {
+		err = rootCmd.Execute()
+	}
+	w.Close()
+	return string(<-c), err
+}
+
+func TestCommandPrecedence(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "tanzu-cli-precedence")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	t.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", dir)
+	setupTestCLIConfig(t, dir)
+
+	cc, err := catalog.NewContextCatalog("")
+	assert.Nil(err)
+	for _, p := range []struct {
+		name   string
+		target configtypes.Target
+	}{
+		{"dummy", configtypes.TargetGlobal},
+		{"dummy", configtypes.TargetK8s},
+		{"version", configtypes.TargetGlobal},
+	} {
+		pluginDir := filepath.Join(dir, string(p.target))
+		assert.Nil(os.MkdirAll(pluginDir, 0755))
+		assert.Nil(setupFakePlugin(pluginDir, p.name, "v0.1.0", plugin.SystemCmdGroup, 0, p.target, 0, false, nil))
+		assert.Nil(cc.Upsert(&cli.PluginInfo{
+			Name:             p.name,
+			Description:      p.name,
+			Group:            plugin.SystemCmdGroup,
+			InstallationPath: filepath.Join(pluginDir, p.name),
+			Target:           p.target,
+			Scope:            common.PluginScopeStandalone,
+		}))
+	}
+
+	// Without command precedence, the collisions are reported
+	out, err := runRootCmd(t, "dummy", "info")
+	assert.Nil(err)
+	// The order of the masked plugins is not deterministic
+	assert.Regexp(`Masking commands for plugins "(dummy, version|version, dummy)"`, out)
+
+	// The selected plugin is run and the collision is no longer reported
+	assert.Nil(cliconfig.SetCommandPrecedence("dummy", "k8s"))
+	out, err = runRootCmd(t, "dummy", "info")
+	assert.Nil(err)
+	assert.Contains(out, `"target": "kubernetes"`)
+	assert.Contains(out, "Masking commands for plugins \"version\"")
+
+	assert.Nil(cliconfig.SetCommandPrecedence("dummy", "global"))
+	assert.Nil(cliconfig.SetCommandPrecedence("version", "core"))
+	out, err = runRootCmd(t, "dummy", "info")
+	assert.Nil(err)
+	assert.Contains(out, `"target": "global"`)
+	assert.NotContains(out, "Masking")
+
+	// A plugin can replace a core command
+	assert.Nil(cliconfig.SetCommandPrecedence("version", "global"))
+	out, err = runRootCmd(t, "version", "info")
+	assert.Nil(err)
+	assert.Contains(out, `"name": "version"`)
+
+	// The plugin command cannot be replaced
+	_, err = runRootCmd(t, "plugin", "precedence", "set", "plugin", "global")
+	assert.NotNil(err)
+
+	// The masked plugins can be run using plugin exec
+	out, err = runRootCmd(t, "plugin", "exec", "dummy", "--target", "k8s", "info")
+	assert.Nil(err)
+	assert.Contains(out, `"target": "kubernetes"`)
+	out, err = runRootCmd(t, "plugin", "exec", "--target=global", "dummy", "say", "hello")
+	assert.Nil(err)
+	assert.Contains(out, "hello")
+	_, err = runRootCmd(t, "plugin", "exec", "dummy", "info")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "several targets")
+	_, err = runRootCmd(t, "plugin", "exec", "missing")
+	assert.NotNil(err)
+}
+
+func TestParseExecArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	name, target, args, err := parseExecArgs([]string{"cluster", "-t", "k8s", "list", "--target", "x"})
+	assert.Nil(err)
+	assert.Equal("cluster", name)
+	assert.Equal("k8s", target)
+	assert.Equal([]string{"list", "--target", "x"}, args)
+
+	name, target, args, err = parseExecArgs([]string{"--target=global", "cluster"})
+	assert.Nil(err)
+	assert.Equal("cluster", name)
+	assert.Equal("global", target)
+	assert.Empty(args)
+
+	_, _, _, err = parseExecArgs([]string{"cluster", "--target"})
+	assert.NotNil(err)
+	_, _, _, err = parseExecArgs([]string{"--target", "invalid", "cluster"})
+	assert.NotNil(err)
+	_, _, _, err = parseExecArgs([]string{"--target", "k8s"})
+	assert.NotNil(err)
+}
+
+func TestUnmanagedPlugins(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "tanzu-cli-unmanaged")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	t.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", dir)
+	setupTestCLIConfig(t, dir)
+
+	pathDir := filepath.Join(dir, "bin")
+	assert.Nil(os.MkdirAll(pathDir, 0755))
+	assert.Nil(os.WriteFile(filepath.Join(pathDir, "tanzu-hello"), []byte("#!/bin/sh\necho unmanaged \"$@\"\n"), 0755))
+	assert.Nil(os.WriteFile(filepath.Join(pathDir, "tanzu-dummy"), []byte("#!/bin/sh\necho unmanaged \"$@\"\n"), 0755))
+	t.Setenv("PATH", pathDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	assert.Nil(setupFakePlugin(dir, "dummy", "v0.1.0", plugin.SystemCmdGroup, 0, configtypes.TargetGlobal, 0, false, nil))
+	cc, err := catalog.NewContextCatalog("")
+	assert.Nil(err)
+	assert.Nil(cc.Upsert(&cli.PluginInfo{
+		Name:             "dummy",
+		Description:      "dummy",
+		Group:            plugin.SystemCmdGroup,
+		InstallationPath: filepath.Join(dir, "dummy"),
+		Target:           configtypes.TargetGlobal,
+	}))
+
+	out, err := runRootCmd(t, "hello", "world")
+	assert.Nil(err)
+	assert.Contains(out, "unmanaged world")
+
+	// The installed plugins take precedence over the executables found on PATH,
+	// which are not looked up for the known commands
+	out, err = runRootCmd(t, "dummy", "say", "hello")
+	assert.Nil(err)
+	assert.NotContains(out, "unmanaged")
+	assert.NotContains(out, "Masking commands")
+
+	// An alias can expand to an executable found on PATH
+	assert.Nil(cliconfig.SetAlias("hi", []string{"hello", "alias"}))
+	out, err = runRootCmd(t, "hi")
+	assert.Nil(err)
+	assert.Contains(out, "unmanaged alias")
+
+	// The executables found on PATH are ignored when disabled
+	t.Setenv(constants.UnmanagedPlugins, "false")
+	_, err = runRootCmd(t, "hello", "world")
+	assert.NotNil(err)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+)
+
+func newRepairPluginCmd() *cobra.Command {
+	var repairCmd = &cobra.Command{
+		Use:   "repair",
+		Short: "Rebuild the catalog of installed plugins",
+		Long: `Rebuild the catalog of installed plugins from the plugin binaries of the plugin
+directory. The information of every plugin is obtained by running its binary.
+If the existing catalog can be read, the plugins remain associated with the same
+contexts and the plugins whose binary is missing are removed from the catalog.
+If the catalog is missing or cannot be read, the most recent version of every
+plugin found is restored as a stand-alone plugin; run 'tanzu plugin sync' to
+install the plugins of the current contexts again.`,
+		Example: `
+    # Rebuild the catalog of installed plugins
+    tanzu plugin repair`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repaired, err := pluginmanager.RepairCatalog()
+			if err != nil {
+				return err
+			}
+			displayRepairedPlugins(repaired, cmd)
+			log.Success("the plugin catalog has been rebuilt")
+			return nil
+		},
+	}
+
+	repairCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	return repairCmd
+}
+
+func displayRepairedPlugins(pluginsByContext map[string][]cli.PluginInfo, cmd *cobra.Command) {
+	contexts := make([]string, 0, len(pluginsByContext))
+	for contextName := range pluginsByContext {
+		contexts = append(contexts, contextName)
+	}
+	sort.Strings(contexts)
+
+	output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "Name", "Target", "Version", "Context", "Path")
+	for _, contextName := range contexts {
+		plugins := pluginsByContext[contextName]
+		sort.Slice(plugins, func(i, j int) bool {
+			return plugins[i].Name < plugins[j].Name
+		})
+		for i := range plugins {
+			output.AddRow(plugins[i].Name, string(plugins[i].Target), plugins[i].Version, contextName, plugins[i].InstallationPath)
+		}
+	}
+	output.Render()
+}
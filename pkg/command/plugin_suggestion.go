@@ -0,0 +1,224 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+var (
+	// unknownCommandRegexp matches the error returned by cobra for an unknown command
+	unknownCommandRegexp = regexp.MustCompile(`^unknown command "([^"]+)" for "([^"]+)"`)
+	// pluginAutoInstallAttempted prevents installing plugins more than once for an invocation
+	pluginAutoInstallAttempted bool
+)
+
+// The discovery and installation of the plugins are variables to be replaced in the tests
+var (
+	discoverStandalonePlugins = pluginmanager.DiscoverStandalonePlugins
+	discoverServerPlugins     = pluginmanager.DiscoverServerPlugins
+	discoverPluginGroups      = pluginmanager.DiscoverPluginGroups
+	installStandalonePlugin   = pluginmanager.InstallStandalonePlugin
+	installPluginFromContext  = pluginmanager.InstallPluginFromContext
+	confirmPluginAutoInstall  = func(message string) error {
+		if !cli.IsTerminal(os.Stdin) {
+			return errors.New("the confirmation cannot be asked without a terminal")
+		}
+		return component.AskForConfirmation(message)
+	}
+)
+
+// pluginSuggestion is a plugin which is not installed and provides an unknown command
+type pluginSuggestion struct {
+	plugin discovery.Discovered
+	// groups are the plugin groups including the plugin
+	groups []string
+}
+
+// describe returns the description of the plugin and of the command installing it
+func (s *pluginSuggestion) describe(commandName string) string {
+	var b strings.Builder
+	if s.plugin.Scope == common.PluginScopeContext {
+		fmt.Fprintf(&b, "The %q command is provided by the %q plugin recommended by the context %q, which is not installed.\n", commandName, s.plugin.Name, s.plugin.ContextName)
+		b.WriteString("Install the plugins of the active contexts using:\n    tanzu plugin sync\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "The %q command is provided by the %q plugin, which is not installed.\n", commandName, s.plugin.Name)
+	fmt.Fprintf(&b, "Install it using:\n    tanzu plugin install %s%s\n", s.plugin.Name, targetFlag(s.plugin.Target))
+	if len(s.groups) > 0 {
+		b.WriteString("or install a plugin group including it using:\n")
+		for _, g := range s.groups {
+			fmt.Fprintf(&b, "    tanzu plugin install --group %s\n", g)
+		}
+	}
+	return b.String()
+}
+
+// install installs the plugin
+func (s *pluginSuggestion) install() error {
+	if s.plugin.Scope == common.PluginScopeContext {
+		return installPluginFromContext(s.plugin.Name, s.plugin.RecommendedVersion, s.plugin.Target, s.plugin.ContextName)
+	}
+	return installStandalonePlugin(s.plugin.Name, cli.VersionLatest, s.plugin.Target)
+}
+
+func targetFlag(target configtypes.Target) string {
+	if target == configtypes.TargetK8s || target == configtypes.TargetTMC {
+		return " --target " + string(target)
+	}
+	return ""
+}
+
+// handleUnknownCommand completes the error of an unknown command with the plugins
+// providing the command which can be installed. If the automatic installation is
+// enabled and a single plugin provides the command, the plugin is installed after
+// a confirmation and the command is run.
+func handleUnknownCommand(err error) error {
+	m := unknownCommandRegexp.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+	commandName := m[1]
+	targets, ok := targetsOfParentCommand(m[2])
+	if !ok {
+		return err
+	}
+
+	suggestions := findPluginSuggestions(commandName, targets)
+	if len(suggestions) == 0 {
+		return err
+	}
+
+	if autoInstall, _ := strconv.ParseBool(os.Getenv(constants.PluginAutoInstall)); autoInstall && len(suggestions) == 1 && !pluginAutoInstallAttempted {
+		pluginAutoInstallAttempted = true
+		s := &suggestions[0]
+		message := fmt.Sprintf("The %q command is provided by the %q plugin, which is not installed. Install the plugin and run the command?", commandName, s.plugin.Name)
+		if confirmErr := confirmPluginAutoInstall(message); confirmErr == nil {
+			if installErr := s.install(); installErr != nil {
+				return errors.Wrapf(installErr, "unable to install the %q plugin", s.plugin.Name)
+			}
+			log.Successf("successfully installed '%s' plugin", s.plugin.Name)
+			return Execute()
+		}
+	}
+
+	var hints []string
+	for i := range suggestions {
+		hints = append(hints, suggestions[i].describe(commandName))
+	}
+	return errors.Errorf("%s\n\n%s", strings.TrimRight(err.Error(), "\n"), strings.TrimRight(strings.Join(hints, "\n"), "\n"))
+}
+
+// targetsOfParentCommand returns the plugin targets available as sub-commands of a command
+func targetsOfParentCommand(commandPath string) ([]configtypes.Target, bool) {
+	switch commandPath {
+	case "tanzu":
+		return []configtypes.Target{configtypes.TargetGlobal, configtypes.TargetUnknown, configtypes.TargetK8s}, true
+	case "tanzu " + k8sCmd.Name():
+		return []configtypes.Target{configtypes.TargetK8s}, true
+	case "tanzu " + tmcCmd.Name():
+		return []configtypes.Target{configtypes.TargetTMC}, true
+	}
+	return nil, false
+}
+
+// findPluginSuggestions returns the plugins of the active contexts and the stand-alone
+// plugins, for one of the targets, providing a command.
+// The aliases of a plugin are only known once it is installed, as neither the plugin
+// inventories nor the CLIPlugin resources of the contexts describe them. The command
+// is therefore matched with the name of the plugin, and with the aliases of the plugins
+// in the catalog, e.g., installed for another context or as another version.
+func findPluginSuggestions(commandName string, targets []configtypes.Target) []pluginSuggestion {
+	aliases, err := catalog.ListPluginAliases()
+	if err != nil {
+		log.V(6).Infof("unable to read the aliases of the installed plugins: %v", err)
+	}
+	matches := func(p *discovery.Discovered) bool {
+		if p.Name != commandName && !utils.ContainsString(aliases[catalog.PluginNameTarget(p.Name, p.Target)], commandName) {
+			return false
+		}
+		for _, t := range targets {
+			if p.Target == t {
+				return true
+			}
+		}
+		return false
+	}
+
+	var suggestions []pluginSuggestion
+	found := map[string]bool{}
+
+	serverPlugins, err := discoverServerPlugins()
+	if err != nil {
+		log.V(6).Infof("unable to discover the plugins of the active contexts: %v", err)
+	}
+	for i := range serverPlugins {
+		key := serverPlugins[i].Name + "/" + string(serverPlugins[i].Target)
+		if matches(&serverPlugins[i]) && !found[key] {
+			found[key] = true
+			suggestions = append(suggestions, pluginSuggestion{plugin: serverPlugins[i]})
+		}
+	}
+
+	standalonePlugins, err := discoverStandalonePlugins()
+	if err != nil {
+		log.V(6).Infof("unable to discover the stand-alone plugins: %v", err)
+	}
+	var groups []*discovery.DiscoveredPluginGroups
+	for i := range standalonePlugins {
+		key := standalonePlugins[i].Name + "/" + string(standalonePlugins[i].Target)
+		if !matches(&standalonePlugins[i]) || found[key] {
+			continue
+		}
+		found[key] = true
+		if groups == nil {
+			if groups, err = discoverPluginGroups(); err != nil {
+				log.V(6).Infof("unable to discover the plugin groups: %v", err)
+			}
+		}
+		suggestions = append(suggestions, pluginSuggestion{
+			plugin: standalonePlugins[i],
+			groups: pluginGroupsIncluding(groups, &standalonePlugins[i]),
+		})
+	}
+	return suggestions
+}
+
+// pluginGroupsIncluding returns the identifiers of the plugin groups including a plugin
+func pluginGroupsIncluding(discoveredGroups []*discovery.DiscoveredPluginGroups, p *discovery.Discovered) []string {
+	var ids []string
+	for _, discovered := range discoveredGroups {
+		for _, group := range discovered.Groups {
+			if group.Hidden {
+				continue
+			}
+			for _, entry := range group.Plugins {
+				if entry.Name == p.Name && entry.Target == p.Target {
+					ids = append(ids, fmt.Sprintf("%s-%s/%s", group.Vendor, group.Publisher, group.Name))
+					break
+				}
+			}
+		}
+	}
+	return ids
+}
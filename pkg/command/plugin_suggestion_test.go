@@ -0,0 +1,158 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// setupPluginSuggestionTest replaces the discovery and the installation of the
+// plugins and returns the function restoring them along with the installed plugins
+func setupPluginSuggestionTest(t *testing.T) (func(), *[]string) {
+	dir, err := os.MkdirTemp("", "tanzu-cli-suggestion")
+	assert.Nil(t, err)
+	t.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", dir)
+	setupTestCLIConfig(t, dir)
+
+	originalStandalone, originalServer, originalGroups := discoverStandalonePlugins, discoverServerPlugins, discoverPluginGroups
+	originalInstallStandalone, originalInstallFromContext, originalConfirm := installStandalonePlugin, installPluginFromContext, confirmPluginAutoInstall
+
+	discoverStandalonePlugins = func() ([]discovery.Discovered, error) {
+		return []discovery.Discovered{
+			{Name: "apps", Target: configtypes.TargetK8s, Scope: common.PluginScopeStandalone},
+			{Name: "builder", Target: configtypes.TargetGlobal, Scope: common.PluginScopeStandalone},
+			{Name: "policy", Target: configtypes.TargetTMC, Scope: common.PluginScopeStandalone},
+			{Name: "cluster", Target: configtypes.TargetK8s, Scope: common.PluginScopeStandalone},
+		}, nil
+	}
+	discoverServerPlugins = func() ([]discovery.Discovered, error) {
+		return []discovery.Discovered{
+			{Name: "cluster", Target: configtypes.TargetK8s, Scope: common.PluginScopeContext, ContextName: "my-cluster", RecommendedVersion: "v1.0.0"},
+		}, errors.New("unreachable context")
+	}
+	discoverPluginGroups = func() ([]*discovery.DiscoveredPluginGroups, error) {
+		return []*discovery.DiscoveredPluginGroups{{
+			Groups: []*plugininventory.PluginGroup{{
+				Vendor:    "vmware",
+				Publisher: "tap",
+				Name:      "default",
+				Plugins: []*plugininventory.PluginGroupPluginEntry{
+					{PluginIdentifier: plugininventory.PluginIdentifier{Name: "apps", Target: configtypes.TargetK8s}},
+				},
+			}},
+		}}, nil
+	}
+	var installed []string
+	installStandalonePlugin = func(name, version string, target configtypes.Target) error {
+		installed = append(installed, name+"/"+string(target))
+		return nil
+	}
+	installPluginFromContext = func(name, version string, target configtypes.Target, contextName string) error {
+		installed = append(installed, name+"/"+string(target)+"/"+contextName)
+		return nil
+	}
+	confirmPluginAutoInstall = func(message string) error { return nil }
+
+	return func() {
+		discoverStandalonePlugins, discoverServerPlugins, discoverPluginGroups = originalStandalone, originalServer, originalGroups
+		installStandalonePlugin, installPluginFromContext, confirmPluginAutoInstall = originalInstallStandalone, originalInstallFromContext, originalConfirm
+		pluginAutoInstallAttempted = false
+		os.RemoveAll(dir)
+	}, &installed
+}
+
+func TestHandleUnknownCommand(t *testing.T) {
+	assert := assert.New(t)
+	restore, installed := setupPluginSuggestionTest(t)
+	defer restore()
+
+	// Stand-alone plugin part of a plugin group
+	err := handleUnknownCommand(errors.New(`unknown command "apps" for "tanzu"`))
+	assert.Contains(err.Error(), `unknown command "apps" for "tanzu"`)
+	assert.Contains(err.Error(), "tanzu plugin install apps --target kubernetes")
+	assert.Contains(err.Error(), "tanzu plugin install --group vmware-tap/default")
+
+	err = handleUnknownCommand(errors.New(`unknown command "builder" for "tanzu"`))
+	assert.True(strings.HasSuffix(err.Error(), "tanzu plugin install builder"))
+	assert.NotContains(err.Error(), "--group")
+
+	// Plugins of the mission-control target are only suggested below the target command
+	err = handleUnknownCommand(errors.New(`unknown command "policy" for "tanzu"`))
+	assert.Equal(`unknown command "policy" for "tanzu"`, err.Error())
+	err = handleUnknownCommand(errors.New(`unknown command "policy" for "tanzu mission-control"`))
+	assert.Contains(err.Error(), "tanzu plugin install policy --target mission-control")
+
+	// The plugins of the active contexts are installed using sync
+	err = handleUnknownCommand(errors.New(`unknown command "cluster" for "tanzu"`))
+	assert.Contains(err.Error(), `recommended by the context "my-cluster"`)
+	assert.Contains(err.Error(), "tanzu plugin sync")
+	assert.NotContains(err.Error(), "tanzu plugin install cluster")
+
+	// The aliases of the plugins installed for another context are matched
+	err = handleUnknownCommand(errors.New(`unknown command "bld" for "tanzu"`))
+	assert.Equal(`unknown command "bld" for "tanzu"`, err.Error())
+	c, err := catalog.NewContextCatalog("other-context")
+	assert.Nil(err)
+	assert.Nil(c.Upsert(&cli.PluginInfo{Name: "builder", Target: configtypes.TargetGlobal, Aliases: []string{"bld"}, InstallationPath: "/path/to/builder"}))
+	err = handleUnknownCommand(errors.New(`unknown command "bld" for "tanzu"`))
+	assert.Contains(err.Error(), `The "bld" command is provided by the "builder" plugin`)
+	assert.True(strings.HasSuffix(err.Error(), "tanzu plugin install builder"))
+
+	// Other errors are not changed
+	err = handleUnknownCommand(errors.New(`unknown command "missing" for "tanzu"`))
+	assert.Equal(`unknown command "missing" for "tanzu"`, err.Error())
+	err = handleUnknownCommand(errors.New("some error"))
+	assert.Equal("some error", err.Error())
+	assert.Empty(*installed)
+}
+
+func TestExecuteWithPluginAutoInstall(t *testing.T) {
+	assert := assert.New(t)
+	restore, installed := setupPluginSuggestionTest(t)
+	defer restore()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	// The error of cobra for an unknown command is completed with the suggestions
+	os.Args = []string{"tanzu", "apps", "workload", "list"}
+	err := Execute()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "tanzu plugin install apps --target kubernetes")
+	assert.Empty(*installed)
+
+	// The plugin is installed and the command run again, at most once
+	t.Setenv(constants.PluginAutoInstall, "true")
+	err = Execute()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "tanzu plugin install apps")
+	assert.Equal([]string{"apps/kubernetes"}, *installed)
+
+	*installed = nil
+	pluginAutoInstallAttempted = false
+	os.Args = []string{"tanzu", "cluster", "list"}
+	_ = Execute()
+	assert.Equal([]string{"cluster/kubernetes/my-cluster"}, *installed)
+
+	// No installation without a confirmation
+	*installed = nil
+	pluginAutoInstallAttempted = false
+	confirmPluginAutoInstall = func(message string) error { return errors.New("aborted") }
+	_ = Execute()
+	assert.Empty(*installed)
+}
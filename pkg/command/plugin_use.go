@@ -0,0 +1,54 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+)
+
+func newUsePluginCmd() *cobra.Command {
+	var useCmd = &cobra.Command{
+		Use:   "use NAME@VERSION",
+		Short: "Switch to another installed version of a plugin",
+		Long: `Switch to another installed version of a stand-alone plugin.
+Every version of a plugin which has been installed is kept side by side, so
+that switching between them does not download the plugin again.
+The installed versions of a plugin are shown by 'tanzu plugin describe'.`,
+		Example: `
+    # Use version v1.0.0 of the cluster plugin
+    tanzu plugin use cluster@v1.0.0
+
+    # Use version v1.0.0 of the cluster plugin for the mission-control target
+    tanzu plugin use cluster@v1.0.0 --target tmc`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pluginName, pluginVersion, found := strings.Cut(args[0], "@")
+			if !found || pluginName == "" || pluginVersion == "" {
+				return errors.Errorf("invalid plugin version %q, it must be of the form NAME@VERSION", args[0])
+			}
+			if !configtypes.IsValidTarget(targetStr, true, true) {
+				return errors.New("invalid target specified. Please specify correct value of `--target` or `-t` flag from 'global/kubernetes/k8s/mission-control/tmc'")
+			}
+
+			if err := pluginmanager.UsePluginVersion(pluginName, pluginVersion, getTarget()); err != nil {
+				return err
+			}
+			log.Successf("now using version %s of plugin '%s'", pluginVersion, pluginName)
+			return nil
+		},
+	}
+
+	useCmd.Flags().StringVarP(&targetStr, "target", "t", "", "target of the plugin (kubernetes[k8s]/mission-control[tmc])")
+
+	return useCmd
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+)
+
+var repairPlugins bool
+
+func newVerifyPluginCmd() *cobra.Command {
+	var verifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the integrity of the installed plugins",
+		Long: `Verify the integrity of the installed plugins by comparing the digest of every
+installed plugin binary, and test plugin binary, with the digest recorded at
+installation time. Missing binaries and binaries of the plugin directory which
+are not known to the CLI (orphaned) are reported as well.`,
+		Example: `
+    # Verify the installed plugins
+    tanzu plugin verify
+
+    # Reinstall the plugins which are missing or have been modified
+    tanzu plugin verify --repair`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			results, err := pluginmanager.VerifyInstalledPlugins()
+			if err != nil {
+				return err
+			}
+
+			if repairPlugins && countPluginsToRepair(results) > 0 {
+				if err := pluginmanager.RepairPlugins(results); err != nil {
+					return err
+				}
+				// Report the state of the plugins after the repair
+				if results, err = pluginmanager.VerifyInstalledPlugins(); err != nil {
+					return err
+				}
+			}
+
+			displayPluginVerificationResults(results, cmd)
+
+			if count := countPluginsToRepair(results); count > 0 {
+				if repairPlugins {
+					return fmt.Errorf("%d plugin binaries could not be repaired", count)
+				}
+				return fmt.Errorf("%d plugin binaries are missing or have been modified. Use 'tanzu plugin verify --repair' to reinstall the affected plugins", count)
+			}
+			log.Success("all installed plugins have been verified")
+			return nil
+		},
+	}
+
+	verifyCmd.Flags().BoolVarP(&repairPlugins, "repair", "", false, "reinstall the plugins which are missing or have been modified from their original discovery source")
+	verifyCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	return verifyCmd
+}
+
+func countPluginsToRepair(results []pluginmanager.PluginVerificationResult) int {
+	count := 0
+	for i := range results {
+		if results[i].Status == pluginmanager.PluginVerificationDigestMismatch ||
+			results[i].Status == pluginmanager.PluginVerificationMissing {
+			count++
+		}
+	}
+	return count
+}
+
+func displayPluginVerificationResults(results []pluginmanager.PluginVerificationResult, cmd *cobra.Command) {
+	output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "Name", "Target", "Version", "Context", "Test", "Status", "Path")
+	for i := range results {
+		r := &results[i]
+		output.AddRow(r.Name, string(r.Target), r.Version, r.ContextName, r.TestPlugin, r.Status, r.Path)
+	}
+	output.Render()
+}
@@ -15,6 +15,7 @@ import (
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
@@ -22,7 +23,15 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
 )
 
-const cmdNameSet = "set"
+const (
+	cmdNameSet = "set"
+
+	// contextFlagName is the name of the root level flag overriding the current context
+	contextFlagName = "context"
+)
+
+// invalidCatalogWarningShown prevents showing the invalid plugin catalog warning more than once
+var invalidCatalogWarningShown bool
 
 // NewRootCmd creates a root command.
 func NewRootCmd() (*cobra.Command, error) {
@@ -33,6 +42,24 @@ func NewRootCmd() (*cobra.Command, error) {
 	// Configure defined environment variables found in the config file
 	cliconfig.ConfigureEnvVariables()
 
+	// Use the context specified for this invocation, if any, instead of the current context of its target
+	args, contextName, err := extractContextFlag(os.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+	if contextName == "" {
+		contextName = os.Getenv(constants.ContextOverride)
+	}
+	if err := cli.SetContextOverride(contextName); err != nil {
+		return nil, err
+	}
+
+	// The plugin catalog is read once to create the commands of all the plugins
+	plugins, err := getInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+
 	rootCmd.AddCommand(
 		newVersionCmd(),
 		newPluginCmd(),
@@ -41,6 +68,8 @@ func NewRootCmd() (*cobra.Command, error) {
 		completionCmd,
 		configCmd,
 		genAllDocsCmd,
+		newDoctorCmd(),
+		newAliasCmd(),
 		// Note(TODO:prkalle): The below ceip-participation command(experimental) added may be removed in the next release,
 		//       If we decide to fold this functionality into existing 'tanzu telemetry' plugin
 		newCEIPParticipationCmd(),
@@ -57,24 +86,20 @@ func NewRootCmd() (*cobra.Command, error) {
 			configtypes.TargetK8s: k8sCmd,
 			configtypes.TargetTMC: tmcCmd,
 		}
-		if err := addPluginsToTarget(mapTargetToCmd); err != nil {
-			return nil, err
-		}
-	}
-
-	plugins, err := pluginsupplier.GetInstalledPlugins()
-	if err != nil {
-		return nil, err
+		addPluginsToTarget(mapTargetToCmd, plugins)
 	}
 	if err = config.CopyLegacyConfigDir(); err != nil {
 		return nil, fmt.Errorf("failed to copy legacy configuration directory to new location: %w", err)
 	}
 
+	// The collisions resolved by the user are not reported
+	resolved := applyCommandPrecedence(rootCmd, plugins)
+
 	var maskedPlugins []string
 
 	for i := range plugins {
 		// Only add plugins that should be available as root level command
-		if isPluginRootCmdTargeted(&plugins[i]) {
+		if isPluginRootCmdTargeted(&plugins[i]) && !resolved[plugins[i].Name] {
 			cmd := cli.GetCmdForPlugin(&plugins[i])
 			// check and find if a command/plugin with the same name already exists as part of the root command
 			matchedCmd := findSubCommand(rootCmd, cmd)
@@ -103,9 +128,41 @@ func NewRootCmd() (*cobra.Command, error) {
 
 	duplicateAliasWarning(rootCmd)
 
+	// Expand the user-defined alias, if any, once all the commands are known
+	if args == nil {
+		args = os.Args[1:]
+	} else {
+		rootCmd.SetArgs(args)
+	}
+	// The executables found on PATH are only looked up for an unknown command,
+	// the installed plugins and the core commands taking precedence
+	addUnmanagedPlugin(rootCmd, args)
+	if expanded := expandAlias(rootCmd, args); expanded != nil {
+		rootCmd.SetArgs(expanded)
+		addUnmanagedPlugin(rootCmd, expanded)
+	}
+	rootCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return aliasCompletions(), cobra.ShellCompDirectiveNoFileComp
+	}
+
 	return rootCmd, nil
 }
 
+// addUnmanagedPlugin adds the command of the executable named tanzu-<name>
+// found on PATH, if any, when the command of the arguments is unknown
+func addUnmanagedPlugin(rootCmd *cobra.Command, args []string) {
+	i := commandNameIndex(args)
+	if i < 0 || strings.HasPrefix(args[i], "-") || isCommandName(rootCmd, args[i]) {
+		return
+	}
+	if p := pluginsupplier.GetUnmanagedPlugin(args[i]); p != nil {
+		rootCmd.AddCommand(cli.GetCmdForPlugin(p))
+	}
+}
+
 func newRootCmd() *cobra.Command {
 	var rootCmd = &cobra.Command{
 		Use: "tanzu",
@@ -156,18 +213,105 @@ var tmcCmd = &cobra.Command{
 	},
 }
 
-func addPluginsToTarget(mapTargetToCmd map[configtypes.Target]*cobra.Command) error {
-	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
-	if err != nil {
-		return fmt.Errorf("unable to find installed plugins: %w", err)
-	}
-
+func addPluginsToTarget(mapTargetToCmd map[configtypes.Target]*cobra.Command, installedPlugins []cli.PluginInfo) {
 	for i := range installedPlugins {
 		if cmd, exists := mapTargetToCmd[installedPlugins[i].Target]; exists {
 			cmd.AddCommand(cli.GetCmdForPlugin(&installedPlugins[i]))
 		}
 	}
-	return nil
+}
+
+// getInstalledPlugins returns the installed plugins. If the plugin catalog
+// cannot be read, a warning is shown and no plugin is returned so that the
+// core commands, including the command repairing the catalog, remain usable.
+func getInstalledPlugins() ([]cli.PluginInfo, error) {
+	plugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil && catalog.IsInvalidCatalogError(err) {
+		if !invalidCatalogWarningShown {
+			fmt.Fprintf(os.Stderr, "Warning, no plugin is available: %v\n\n", err)
+			invalidCatalogWarningShown = true
+		}
+		return nil, nil
+	}
+	return plugins, err
+}
+
+// extractContextFlag extracts the value of the root level --context flag from the
+// arguments of the CLI. The flag must be specified before the command, flags specified
+// after the command belong to the command. The arguments without the flag are returned,
+// or nil if the flag is not specified.
+func extractContextFlag(args []string) ([]string, string, error) {
+	var contextName string
+	i := 0
+	for ; i < len(args); i++ {
+		if args[i] == "--"+contextFlagName {
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("flag needs an argument: --%s", contextFlagName)
+			}
+			i++
+			contextName = args[i]
+		} else if strings.HasPrefix(args[i], "--"+contextFlagName+"=") {
+			contextName = strings.TrimPrefix(args[i], "--"+contextFlagName+"=")
+		} else {
+			break
+		}
+	}
+	if i == 0 {
+		return nil, "", nil
+	}
+	return args[i:], contextName, nil
+}
+
+// applyCommandPrecedence adds the root level commands whose collision between a core
+// command and plugins, or between plugins, is resolved by the command precedence
+// configured by the user. The names of the resolved commands are returned.
+func applyCommandPrecedence(rootCmd *cobra.Command, plugins []cli.PluginInfo) map[string]bool {
+	resolved := map[string]bool{}
+	precedences, err := cliconfig.GetCommandPrecedenceMap()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning, unable to read the command precedence: %v\n\n", err)
+		return resolved
+	}
+
+	for name, winner := range precedences {
+		var candidates []*cli.PluginInfo
+		for i := range plugins {
+			if plugins[i].Name == name && isPluginRootCmdTargeted(&plugins[i]) {
+				candidates = append(candidates, &plugins[i])
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		// Only core commands have been added at this point
+		coreCmd := findSubCommand(rootCmd, &cobra.Command{Use: name})
+
+		if winner == cliconfig.CommandPrecedenceCore {
+			resolved[name] = coreCmd != nil
+			continue
+		}
+		var selected *cli.PluginInfo
+		for _, p := range candidates {
+			target := p.Target
+			if target == configtypes.TargetUnknown {
+				target = configtypes.TargetGlobal
+			}
+			if string(target) == winner {
+				selected = p
+				break
+			}
+		}
+		// The plugin command cannot be replaced as it is needed to change the command precedence
+		if selected == nil || (coreCmd != nil && coreCmd.Name() == "plugin") {
+			continue
+		}
+		if coreCmd != nil {
+			rootCmd.RemoveCommand(coreCmd)
+		}
+		rootCmd.AddCommand(cli.GetCmdForPlugin(selected))
+		resolved[name] = true
+	}
+	return resolved
 }
 
 func findSubCommand(rootCmd, subCmd *cobra.Command) *cobra.Command {
@@ -218,9 +362,21 @@ func duplicateAliasWarning(rootCmd *cobra.Command) {
 
 // Execute executes the CLI.
 func Execute() error {
+	// The plugins of a context are checked once the current contexts have
+	// changed, before the commands of the installed plugins are created
+	checkContextPluginsAfterSwitch(os.Args[1:])
+
+	// Newer versions of the installed plugins are only reported once the command has completed
+	notifyUpdates := startUpdateCheck(os.Args[1:])
+	defer notifyUpdates()
+
 	root, err := NewRootCmd()
 	if err != nil {
 		return err
 	}
-	return root.Execute()
+	if err := root.Execute(); err != nil {
+		// Suggest the plugins providing an unknown command
+		return handleUnknownCommand(err)
+	}
+	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	configcli "github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 )
@@ -73,6 +75,14 @@ esac
 `
 )
 
+// setupTestCLIConfig uses a CLI configuration stored in the directory,
+// which does not prompt for the CEIP participation
+func setupTestCLIConfig(t *testing.T, dir string) {
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+	assert.Nil(t, config.SetCEIPOptIn("false"))
+}
+
 func TestExecute(t *testing.T) {
 	assert := assert.New(t)
 	err := Execute()
@@ -95,6 +105,102 @@ func TestRootCmdWithNoAdditionalPlugins(t *testing.T) {
 	assert.Nil(err)
 }
 
+func TestRootCmdWithInvalidCatalog(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "tanzu-cli-root-cmd")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	t.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", dir)
+	assert.Nil(os.WriteFile(filepath.Join(dir, "catalog.yaml"), []byte("indexByPath: [\n"), 0o644))
+
+	// The core commands remain available to repair the catalog
+	rootCmd, err := NewRootCmd()
+	assert.Nil(err)
+	repairCmd, _, err := rootCmd.Find([]string{"plugin", "repair"})
+	assert.Nil(err)
+	assert.Equal("repair", repairCmd.Name())
+}
+
+func TestExtractContextFlag(t *testing.T) {
+	tests := []struct {
+		args            []string
+		expectedArgs    []string
+		expectedContext string
+		expectedFailure bool
+	}{
+		{args: []string{"cluster", "list"}},
+		{args: []string{"cluster", "list", "--context", "mc"}},
+		{args: []string{"--context", "mc", "cluster", "list"}, expectedArgs: []string{"cluster", "list"}, expectedContext: "mc"},
+		{args: []string{"--context=mc", "cluster", "list"}, expectedArgs: []string{"cluster", "list"}, expectedContext: "mc"},
+		{args: []string{"--context", "mc"}, expectedArgs: []string{}, expectedContext: "mc"},
+		{args: []string{"--context"}, expectedFailure: true},
+	}
+
+	for _, spec := range tests {
+		t.Run(strings.Join(spec.args, " "), func(t *testing.T) {
+			args, contextName, err := extractContextFlag(spec.args)
+			if spec.expectedFailure {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, spec.expectedArgs, args)
+			assert.Equal(t, spec.expectedContext, contextName)
+		})
+	}
+}
+
+func TestRootCmdWithUnknownContextOverride(t *testing.T) {
+	t.Setenv(constants.ContextOverride, "unknown-context")
+	_, err := NewRootCmd()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `context "unknown-context" not found`)
+}
+
+func TestRootCmdUsesPluginOfCurrentContext(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "tanzu-cli-root-cmd")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	t.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", dir)
+	setupTestCLIConfig(t, dir)
+
+	// Each context has its own version of the cluster plugin
+	for _, name := range []string{"mc-1", "mc-2"} {
+		assert.Nil(config.SetContext(&configtypes.Context{
+			Name:        name,
+			Target:      configtypes.TargetK8s,
+			ClusterOpts: &configtypes.ClusterServer{Endpoint: name + "-endpoint", Path: filepath.Join(dir, "kubeconfig"), Context: name},
+		}, name == "mc-1"))
+
+		pluginPath := filepath.Join(dir, name, "cluster")
+		assert.Nil(os.MkdirAll(filepath.Dir(pluginPath), 0755))
+		assert.Nil(os.WriteFile(pluginPath, []byte("#!/bin/bash\necho \"running the cluster plugin of "+name+"\"\n"), 0755))
+		cc, err := catalog.NewContextCatalog(name)
+		assert.Nil(err)
+		assert.Nil(cc.Upsert(&cli.PluginInfo{
+			Name:             "cluster",
+			Description:      "cluster",
+			Group:            plugin.RunCmdGroup,
+			InstallationPath: pluginPath,
+			Target:           configtypes.TargetK8s,
+			Scope:            common.PluginScopeContext,
+		}))
+	}
+
+	out, err := runRootCmd(t, "cluster", "list")
+	assert.Nil(err)
+	assert.Contains(out, "running the cluster plugin of mc-1")
+
+	// Switching context switches the plugin binary without reinstalling the plugin
+	assert.Nil(config.SetCurrentContext("mc-2"))
+	out, err = runRootCmd(t, "cluster", "list")
+	assert.Nil(err)
+	assert.Contains(out, "running the cluster plugin of mc-2")
+}
+
 func TestSubcommandNonexistent(t *testing.T) {
 	assert := assert.New(t)
 	rootCmd, err := NewRootCmd()
@@ -0,0 +1,286 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// updateCheckFileName is the name of the file, stored in the CLI cache directory,
+// which holds the time of the last check for newer versions of the CLI and of the
+// installed plugins, and the results of the check which were not reported yet
+const updateCheckFileName = "update_check.yaml"
+
+// defaultUpdateCheckInterval is the minimum duration between two checks for newer
+// versions of the CLI and of the installed plugins when none is configured
+const defaultUpdateCheckInterval = 24 * time.Hour
+
+// The check, the terminal detection and the output of the notice are variables
+// to be replaced in the tests
+var (
+	pluginUpdates                    = pluginmanager.GetPluginUpdates
+	recommendedCLIVersion            = pluginmanager.GetRecommendedCLIVersion
+	isUpdateNoticeTerminal           = func() bool { return cli.IsTerminal(os.Stderr) }
+	updateNoticeWriter     io.Writer = os.Stderr
+	installedPlugins                 = pluginsupplier.GetInstalledStandalonePlugins
+)
+
+// updateCheckStarted prevents a second check when the command is run again
+// after installing the plugin providing it
+var updateCheckStarted bool
+
+// commandsWithoutUpdateCheck are the commands which do not check for newer versions
+// of the CLI and of the installed plugins, as they either manage the plugins or must not print
+// anything but their own output
+var commandsWithoutUpdateCheck = []string{
+	"plugin", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd,
+}
+
+// updateCheck is the state of the check for newer versions. The results of a
+// check are kept until they are reported, so that a check finishing after the
+// command which started it is reported by the next command.
+type updateCheck struct {
+	LastCheck     time.Time                    `yaml:"lastCheck"`
+	PluginUpdates []pluginmanager.PluginUpdate `yaml:"pluginUpdates,omitempty"`
+	CLIVersion    string                       `yaml:"cliVersion,omitempty"`
+}
+
+// hasResults returns true if the check found newer versions which were not reported yet
+func (c *updateCheck) hasResults() bool {
+	return len(c.PluginUpdates) > 0 || c.CLIVersion != ""
+}
+
+func getUpdateCheckFilePath() string {
+	return filepath.Join(common.DefaultCacheDir, updateCheckFileName)
+}
+
+// getUpdateCheckInterval returns the configured minimum duration between two checks
+func getUpdateCheckInterval() time.Duration {
+	value := os.Getenv(constants.UpdateCheckInterval)
+	if value == "" {
+		return defaultUpdateCheckInterval
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		log.V(6).Infof("invalid value %q for %s, using %s", value, constants.UpdateCheckInterval, defaultUpdateCheckInterval)
+		return defaultUpdateCheckInterval
+	}
+	return interval
+}
+
+// isCIEnvironment returns true if the CLI runs in a continuous integration environment
+func isCIEnvironment() bool {
+	value := os.Getenv("CI")
+	if value == "" {
+		return false
+	}
+	ci, err := strconv.ParseBool(value)
+	return err != nil || ci
+}
+
+// isUpdateCheckEnabled returns true if the command should check for newer versions
+// of the CLI and of the installed plugins. The check is disabled by the user, in continuous
+// integration environments and when the notice would not be printed to a terminal.
+func isUpdateCheckEnabled(args []string) bool {
+	if enabled, err := strconv.ParseBool(os.Getenv(constants.UpdateCheck)); err == nil && !enabled {
+		return false
+	}
+	if utils.ContainsString(commandsWithoutUpdateCheck, commandNameOfArgs(args)) {
+		return false
+	}
+	return !isCIEnvironment() && isUpdateNoticeTerminal()
+}
+
+// commandNameOfArgs returns the name of the root level command run by the arguments
+// of the CLI once the root level --context flag is removed and a user-defined alias
+// is expanded, as done when creating the root command. The commands take precedence
+// over the aliases, but the plugins are not known yet, so only the aliases named as
+// a command without update check are ignored.
+func commandNameOfArgs(args []string) string {
+	if withoutContext, _, err := extractContextFlag(args); err == nil && withoutContext != nil {
+		args = withoutContext
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	if utils.ContainsString(commandsWithoutUpdateCheck, args[0]) {
+		return args[0]
+	}
+	if command, exists, err := cliconfig.GetAlias(args[0]); err == nil && exists {
+		return command[0]
+	}
+	return args[0]
+}
+
+// readUpdateCheck returns the state of the last check. A check which cannot be
+// read is considered to have never been done.
+func readUpdateCheck() *updateCheck {
+	var check updateCheck
+	b, err := os.ReadFile(getUpdateCheckFilePath())
+	if err != nil {
+		return &check
+	}
+	if err := yaml.Unmarshal(b, &check); err != nil {
+		return &updateCheck{}
+	}
+	return &check
+}
+
+// saveUpdateCheck records the state of the last check
+func saveUpdateCheck(check *updateCheck) {
+	b, err := yaml.Marshal(check)
+	if err == nil {
+		err = utils.SaveFile(getUpdateCheckFilePath(), b)
+	}
+	if err != nil {
+		log.V(6).Infof("unable to record the update check: %v", err)
+	}
+}
+
+// newerCLIVersion returns the recommended version of the CLI if it is newer
+// than the running CLI. Development builds without a valid version are not compared.
+func newerCLIVersion(recommended string) string {
+	current := buildinfo.Version
+	if !strings.HasPrefix(current, "v") {
+		current = "v" + current
+	}
+	if !semver.IsValid(recommended) || !semver.IsValid(current) || semver.Compare(recommended, current) <= 0 {
+		return ""
+	}
+	return recommended
+}
+
+// checkForUpdates checks for newer versions of the CLI and of the installed plugins
+// using the cached plugin inventories. The time of the check is recorded before
+// checking, so that the check is not done again before the configured interval even
+// if the command exits before the check finishes. The results are recorded too, for
+// the next command to report them if this command does not.
+func checkForUpdates() *updateCheck {
+	check := &updateCheck{LastCheck: time.Now()}
+	saveUpdateCheck(check)
+
+	updates, err := pluginUpdates()
+	if err != nil {
+		log.V(6).Infof("unable to check for newer versions of the installed plugins: %v", err)
+	}
+	check.PluginUpdates = updates
+	version, err := recommendedCLIVersion()
+	if err != nil {
+		log.V(6).Infof("unable to check for a newer version of the CLI: %v", err)
+	}
+	check.CLIVersion = newerCLIVersion(version)
+	if check.hasResults() {
+		saveUpdateCheck(check)
+	}
+	return check
+}
+
+// startUpdateCheck checks for newer versions of the CLI and of the installed plugins in
+// the background, at most once per configured interval, using the cached plugin
+// inventories. It returns the function to call once the command has completed, which
+// prints the notice if the check has finished. The command never waits for the check:
+// the results of a check which has not finished in time are printed by the next command.
+func startUpdateCheck(args []string) func() {
+	if updateCheckStarted || !isUpdateCheckEnabled(args) {
+		return func() {}
+	}
+	updateCheckStarted = true
+
+	result := make(chan *updateCheck, 1)
+	go func() {
+		check := readUpdateCheck()
+		if !check.hasResults() {
+			if time.Since(check.LastCheck) < getUpdateCheckInterval() {
+				close(result)
+				return
+			}
+			check = checkForUpdates()
+		}
+		result <- check
+	}()
+
+	return func() {
+		select {
+		case check, ok := <-result:
+			if !ok || !check.hasResults() {
+				return
+			}
+			printUpdateNotice(check)
+			saveUpdateCheck(&updateCheck{LastCheck: check.LastCheck})
+		default:
+		}
+	}
+}
+
+// pendingPluginUpdates returns the plugin updates found by a check which are still
+// to be done, the plugins having possibly been upgraded or deleted since the check
+func pendingPluginUpdates(updates []pluginmanager.PluginUpdate) []pluginmanager.PluginUpdate {
+	if len(updates) == 0 {
+		return nil
+	}
+	plugins, err := installedPlugins()
+	if err != nil {
+		log.V(6).Infof("unable to read the installed plugins: %v", err)
+		return updates
+	}
+
+	var pending []pluginmanager.PluginUpdate
+	for i := range updates {
+		for j := range plugins {
+			if plugins[j].Name == updates[i].Name && plugins[j].Target == updates[i].Target &&
+				semver.Compare(updates[i].RecommendedVersion, plugins[j].Version) > 0 {
+				update := updates[i]
+				update.InstalledVersion = plugins[j].Version
+				pending = append(pending, update)
+				break
+			}
+		}
+	}
+	return pending
+}
+
+// printUpdateNotice prints a short notice about the newer recommended version of the CLI
+// and the installed plugins for which a newer version is recommended
+func printUpdateNotice(check *updateCheck) {
+	// The CLI and the plugins may have been upgraded since the check
+	version := newerCLIVersion(check.CLIVersion)
+	updates := pendingPluginUpdates(check.PluginUpdates)
+	if version == "" && len(updates) == 0 {
+		return
+	}
+	if version != "" {
+		fmt.Fprintf(updateNoticeWriter, "\nA newer version of the Tanzu CLI is recommended: %s -> %s\n", buildinfo.Version, version)
+	}
+	if len(updates) > 0 {
+		var descriptions []string
+		for i := range updates {
+			u := &updates[i]
+			descriptions = append(descriptions, fmt.Sprintf("%s (%s): %s -> %s", u.Name, u.Target, u.InstalledVersion, u.RecommendedVersion))
+		}
+		fmt.Fprintf(updateNoticeWriter, "\nNewer versions of the following installed plugins are recommended:\n  %s\n"+
+			"Run 'tanzu plugin upgrade <plugin-name>' to upgrade them.\n", strings.Join(descriptions, "\n  "))
+	}
+	fmt.Fprintf(updateNoticeWriter, "Set %s=false to disable this check.\n", constants.UpdateCheck)
+}
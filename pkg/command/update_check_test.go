@@ -0,0 +1,293 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+)
+
+func TestUpdateCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "tanzu-cli-update-check")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	t.Setenv(constants.UpdateCheck, "")
+	t.Setenv(constants.UpdateCheckInterval, "")
+	t.Setenv("CI", "")
+	originalCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = dir
+	buildinfo.Version = "v1.0.0"
+
+	originalUpdates, originalCLIVersion, originalTerminal, originalWriter := pluginUpdates, recommendedCLIVersion, isUpdateNoticeTerminal, updateNoticeWriter
+	originalInstalled := installedPlugins
+	defer func() {
+		pluginUpdates, recommendedCLIVersion, isUpdateNoticeTerminal, updateNoticeWriter = originalUpdates, originalCLIVersion, originalTerminal, originalWriter
+		installedPlugins = originalInstalled
+		common.DefaultCacheDir = originalCacheDir
+		buildinfo.Version = ""
+		updateCheckStarted = false
+	}()
+
+	checks := make(chan struct{}, 10)
+	pluginUpdates = func() ([]pluginmanager.PluginUpdate, error) {
+		checks <- struct{}{}
+		return []pluginmanager.PluginUpdate{{Name: "cluster", Target: configtypes.TargetK8s, InstalledVersion: "v1.0.0", RecommendedVersion: "v1.1.0"}}, nil
+	}
+	recommendedCLIVersion = func() (string, error) {
+		return "v1.2.0", nil
+	}
+	installedPlugins = func() ([]cli.PluginInfo, error) {
+		return []cli.PluginInfo{{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.0.0"}}, nil
+	}
+	isUpdateNoticeTerminal = func() bool { return true }
+	var out bytes.Buffer
+	updateNoticeWriter = &out
+
+	runCheck := func(args ...string) {
+		updateCheckStarted = false
+		notify := startUpdateCheck(args)
+		// Wait for the background check, as a slow command would
+		assert.Eventually(func() bool {
+			notify()
+			return out.Len() > 0
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	// The notice is printed once the check has finished
+	runCheck("cluster", "list")
+	assert.Equal(1, len(checks))
+	assert.Contains(out.String(), "A newer version of the Tanzu CLI is recommended: v1.0.0 -> v1.2.0")
+	assert.Contains(out.String(), "cluster (kubernetes): v1.0.0 -> v1.1.0")
+	assert.Contains(out.String(), "tanzu plugin upgrade")
+	assert.FileExists(filepath.Join(dir, updateCheckFileName))
+	assert.False(readUpdateCheck().hasResults())
+	<-checks
+
+	// The check is done at most once per interval
+	out.Reset()
+	updateCheckStarted = false
+	notify := startUpdateCheck([]string{"cluster", "list"})
+	time.Sleep(50 * time.Millisecond)
+	notify()
+	assert.Equal(0, len(checks))
+	assert.Empty(out.String())
+
+	// The check is done again once the interval has passed
+	t.Setenv(constants.UpdateCheckInterval, "0s")
+	runCheck("cluster", "list")
+	assert.Equal(1, len(checks))
+	assert.Contains(out.String(), "v1.1.0")
+	<-checks
+
+	// The CLI version is not reported once the CLI is up to date
+	out.Reset()
+	buildinfo.Version = "v1.2.0"
+	runCheck("cluster", "list")
+	assert.NotContains(out.String(), "Tanzu CLI")
+	assert.Contains(out.String(), "cluster (kubernetes): v1.0.0 -> v1.1.0")
+	<-checks
+	buildinfo.Version = "v1.0.0"
+}
+
+func TestUpdateCheckNotFinishedInTime(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "tanzu-cli-update-check")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	t.Setenv(constants.UpdateCheck, "")
+	t.Setenv(constants.UpdateCheckInterval, "")
+	t.Setenv("CI", "")
+	originalCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = dir
+	buildinfo.Version = "v1.0.0"
+
+	originalUpdates, originalCLIVersion, originalTerminal, originalWriter := pluginUpdates, recommendedCLIVersion, isUpdateNoticeTerminal, updateNoticeWriter
+	originalInstalled := installedPlugins
+	defer func() {
+		pluginUpdates, recommendedCLIVersion, isUpdateNoticeTerminal, updateNoticeWriter = originalUpdates, originalCLIVersion, originalTerminal, originalWriter
+		installedPlugins = originalInstalled
+		common.DefaultCacheDir = originalCacheDir
+		buildinfo.Version = ""
+		updateCheckStarted = false
+	}()
+
+	checks := make(chan struct{}, 10)
+	release := make(chan struct{})
+	pluginUpdates = func() ([]pluginmanager.PluginUpdate, error) {
+		checks <- struct{}{}
+		<-release
+		return []pluginmanager.PluginUpdate{{Name: "cluster", Target: configtypes.TargetK8s, InstalledVersion: "v1.0.0", RecommendedVersion: "v1.1.0"}}, nil
+	}
+	recommendedCLIVersion = func() (string, error) {
+		return "", nil
+	}
+	installedPlugins = func() ([]cli.PluginInfo, error) {
+		return []cli.PluginInfo{{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.0.0"}}, nil
+	}
+	isUpdateNoticeTerminal = func() bool { return true }
+	var out bytes.Buffer
+	updateNoticeWriter = &out
+
+	// The command completes before the check
+	firstNotify := startUpdateCheck([]string{"cluster", "list"})
+	firstNotify()
+	<-checks
+	assert.Empty(out.String())
+
+	// The attempt is recorded when the check starts
+	check := readUpdateCheck()
+	assert.False(check.LastCheck.IsZero())
+	assert.False(check.hasResults())
+
+	// The next command does not check again within the interval
+	updateCheckStarted = false
+	notify := startUpdateCheck([]string{"cluster", "list"})
+	time.Sleep(50 * time.Millisecond)
+	notify()
+	assert.Equal(0, len(checks))
+	assert.Empty(out.String())
+
+	// The results of the check are recorded once it finishes
+	close(release)
+	assert.Eventually(func() bool {
+		return readUpdateCheck().hasResults()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// and printed by the next command without checking again
+	updateCheckStarted = false
+	notify = startUpdateCheck([]string{"cluster", "list"})
+	assert.Eventually(func() bool {
+		notify()
+		return out.Len() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(0, len(checks))
+	assert.Contains(out.String(), "cluster (kubernetes): v1.0.0 -> v1.1.0")
+	assert.NotContains(out.String(), "Tanzu CLI")
+
+	// The results are only printed once
+	check = readUpdateCheck()
+	assert.False(check.hasResults())
+	assert.False(check.LastCheck.IsZero())
+	out.Reset()
+	updateCheckStarted = false
+	notify = startUpdateCheck([]string{"cluster", "list"})
+	time.Sleep(50 * time.Millisecond)
+	notify()
+	assert.Empty(out.String())
+
+	// Wait for the check of the first command to complete
+	assert.Eventually(func() bool {
+		firstNotify()
+		return out.Len() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestPrintUpdateNoticeAfterUpgrade(t *testing.T) {
+	assert := assert.New(t)
+
+	originalInstalled, originalWriter := installedPlugins, updateNoticeWriter
+	defer func() { installedPlugins, updateNoticeWriter = originalInstalled, originalWriter }()
+	var out bytes.Buffer
+	updateNoticeWriter = &out
+
+	check := &updateCheck{PluginUpdates: []pluginmanager.PluginUpdate{
+		{Name: "cluster", Target: configtypes.TargetK8s, InstalledVersion: "v1.0.0", RecommendedVersion: "v1.2.0"},
+		{Name: "apps", Target: configtypes.TargetK8s, InstalledVersion: "v1.0.0", RecommendedVersion: "v1.2.0"},
+		{Name: "builder", Target: configtypes.TargetGlobal, InstalledVersion: "v1.0.0", RecommendedVersion: "v1.2.0"},
+	}}
+
+	// The plugins upgraded to the recommended version, or deleted, since the check are not reported
+	installedPlugins = func() ([]cli.PluginInfo, error) {
+		return []cli.PluginInfo{
+			{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.1.0"},
+			{Name: "apps", Target: configtypes.TargetK8s, Version: "v1.2.0"},
+		}, nil
+	}
+	printUpdateNotice(check)
+	assert.Contains(out.String(), "cluster (kubernetes): v1.1.0 -> v1.2.0")
+	assert.NotContains(out.String(), "apps")
+	assert.NotContains(out.String(), "builder")
+
+	// Nothing is printed once all the plugins are upgraded
+	out.Reset()
+	installedPlugins = func() ([]cli.PluginInfo, error) {
+		return []cli.PluginInfo{{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.2.0"}}, nil
+	}
+	printUpdateNotice(check)
+	assert.Empty(out.String())
+}
+
+func TestIsUpdateCheckEnabled(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	setupTestCLIConfig(t, dir)
+
+	t.Setenv(constants.UpdateCheck, "")
+	t.Setenv("CI", "")
+	originalTerminal := isUpdateNoticeTerminal
+	defer func() { isUpdateNoticeTerminal = originalTerminal }()
+	terminal := true
+	isUpdateNoticeTerminal = func() bool { return terminal }
+
+	assert.True(isUpdateCheckEnabled([]string{"cluster", "list"}))
+	assert.True(isUpdateCheckEnabled(nil))
+
+	// The commands managing the plugins and the completion do not check for updates
+	for _, args := range [][]string{{"plugin", "list"}, {"completion", "bash"}, {"__complete", "c"}} {
+		assert.False(isUpdateCheckEnabled(args))
+	}
+
+	// The command is found after the root level --context flag and in the aliases
+	assert.Nil(cliconfig.SetAlias("pl", []string{"plugin", "list"}))
+	assert.Nil(cliconfig.SetAlias("cl", []string{"cluster", "list"}))
+	for _, args := range [][]string{{"--context", "my-context", "plugin", "list"}, {"--context=my-context", "plugin", "list"}, {"pl"}, {"--context", "my-context", "pl"}} {
+		assert.False(isUpdateCheckEnabled(args), args)
+	}
+	assert.True(isUpdateCheckEnabled([]string{"--context", "my-context", "cluster", "list"}))
+	assert.True(isUpdateCheckEnabled([]string{"cl"}))
+
+	// The check is suppressed without a terminal
+	terminal = false
+	assert.False(isUpdateCheckEnabled([]string{"cluster", "list"}))
+	terminal = true
+
+	// The check is suppressed in continuous integration environments
+	t.Setenv("CI", "true")
+	assert.False(isUpdateCheckEnabled([]string{"cluster", "list"}))
+	t.Setenv("CI", "false")
+	assert.True(isUpdateCheckEnabled([]string{"cluster", "list"}))
+
+	// The check can be disabled
+	t.Setenv(constants.UpdateCheck, "false")
+	assert.False(isUpdateCheckEnabled([]string{"cluster", "list"}))
+}
+
+func TestGetUpdateCheckInterval(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv(constants.UpdateCheckInterval, "")
+	assert.Equal(defaultUpdateCheckInterval, getUpdateCheckInterval())
+	t.Setenv(constants.UpdateCheckInterval, "12h")
+	assert.Equal(12*time.Hour, getUpdateCheckInterval())
+	t.Setenv(constants.UpdateCheckInterval, "invalid")
+	assert.Equal(defaultUpdateCheckInterval, getUpdateCheckInterval())
+}
@@ -9,6 +9,7 @@ const (
 	PluginStatusInstalled       = "installed"
 	PluginStatusNotInstalled    = "not installed"
 	PluginStatusUpdateAvailable = "update available"
+	PluginStatusUnmanaged       = "unmanaged"
 	PluginScopeStandalone       = "Standalone"
 	PluginScopeContext          = "Context"
 )
@@ -0,0 +1,99 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/clientconfighelpers"
+)
+
+// aliasesSetting is the CLI setting holding the user-defined command aliases
+const aliasesSetting = "aliases"
+
+// Alias is a user-defined shortcut for a command of the CLI
+type Alias struct {
+	// Name is the name of the alias, used as the command name
+	Name string `yaml:"name" json:"name"`
+	// Command is the command line, without the tanzu prefix, the alias expands to.
+	// Each argument is kept as is, e.g., [apps workload list --label "app=my app"]
+	Command []string `yaml:"command" json:"command"`
+}
+
+func readAliases() (map[string][]string, error) {
+	m := map[string][]string{}
+	if _, err := clientconfighelpers.GetCLISetting(aliasesSetting, &m); err != nil {
+		return nil, errors.Wrap(err, "unable to read the aliases")
+	}
+	if m == nil {
+		m = map[string][]string{}
+	}
+	return m, nil
+}
+
+func saveAliases(m map[string][]string) error {
+	if len(m) == 0 {
+		return clientconfighelpers.DeleteCLISetting(aliasesSetting)
+	}
+	return clientconfighelpers.SetCLISetting(aliasesSetting, m)
+}
+
+// GetAliases returns all the user-defined aliases sorted by name
+func GetAliases() ([]Alias, error) {
+	m, err := readAliases()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Alias, 0, len(m))
+	for name, command := range m {
+		result = append(result, Alias{Name: name, Command: command})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// GetAlias returns the command line an alias expands to, if the alias exists
+func GetAlias(name string) ([]string, bool, error) {
+	m, err := readAliases()
+	if err != nil {
+		return nil, false, err
+	}
+	command, exists := m[name]
+	return command, exists, nil
+}
+
+// SetAlias creates or updates an alias. The arguments of the command line
+// are stored as is, so that arguments containing spaces are preserved.
+func SetAlias(name string, command []string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t\n") {
+		return errors.Errorf("invalid alias name %q", name)
+	}
+	if len(command) == 0 || strings.TrimSpace(command[0]) == "" {
+		return errors.New("the command of the alias cannot be empty")
+	}
+	m, err := readAliases()
+	if err != nil {
+		return err
+	}
+	m[name] = command
+	return saveAliases(m)
+}
+
+// DeleteAlias deletes an alias
+func DeleteAlias(name string) error {
+	m, err := readAliases()
+	if err != nil {
+		return err
+	}
+	if _, exists := m[name]; !exists {
+		return errors.Errorf("alias %q not found", name)
+	}
+	delete(m, name)
+	return saveAliases(m)
+}
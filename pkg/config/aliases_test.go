@@ -0,0 +1,67 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+)
+
+func setupAliasesTest(t *testing.T) func() {
+	dir, err := os.MkdirTemp("", "aliases")
+	assert.Nil(t, err)
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+	return func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestAliases(t *testing.T) {
+	assert := assert.New(t)
+	defer setupAliasesTest(t)()
+
+	aliases, err := GetAliases()
+	assert.Nil(err)
+	assert.Empty(aliases)
+
+	assert.Nil(SetAlias("wl", []string{"apps", "workload", "list", "--label", "app=my app"}))
+	assert.Nil(SetAlias("mc", []string{"cluster"}))
+	assert.Nil(SetAlias("mc", []string{"management-cluster"}))
+
+	aliases, err = GetAliases()
+	assert.Nil(err)
+	assert.Equal([]Alias{
+		{Name: "mc", Command: []string{"management-cluster"}},
+		{Name: "wl", Command: []string{"apps", "workload", "list", "--label", "app=my app"}},
+	}, aliases)
+
+	// The arguments containing spaces are preserved
+	command, exists, err := GetAlias("wl")
+	assert.Nil(err)
+	assert.True(exists)
+	assert.Equal([]string{"apps", "workload", "list", "--label", "app=my app"}, command)
+
+	// The aliases are stored in the CLI configuration
+	assert.Nil(configlib.SetEnv("FOO", "bar"))
+	_, exists, err = GetAlias("wl")
+	assert.Nil(err)
+	assert.True(exists)
+
+	assert.NotNil(SetAlias("-x", []string{"version"}))
+	assert.NotNil(SetAlias("my alias", []string{"version"}))
+	assert.NotNil(SetAlias("v", []string{" "}))
+	assert.NotNil(SetAlias("v", nil))
+
+	assert.Nil(DeleteAlias("mc"))
+	assert.NotNil(DeleteAlias("mc"))
+	_, exists, err = GetAlias("mc")
+	assert.Nil(err)
+	assert.False(exists)
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/clientconfighelpers"
+)
+
+// commandPrecedenceSetting is the CLI setting holding the command to run
+// when several commands share a name
+const commandPrecedenceSetting = "commandPrecedence"
+
+// CommandPrecedenceCore selects the core command of the CLI when
+// plugins provide a command with the same name
+const CommandPrecedenceCore = "core"
+
+// CommandPrecedence selects the command to run when a core command and
+// plugins, or several plugins, provide a command with the same name
+type CommandPrecedence struct {
+	// Command is the name of the command
+	Command string `yaml:"command" json:"command"`
+	// Winner is either "core" for the core command of the CLI, or the
+	// target of the plugin to run, i.e., "global" or "kubernetes"
+	Winner string `yaml:"winner" json:"winner"`
+}
+
+func readCommandPrecedences() (map[string]string, error) {
+	m := map[string]string{}
+	if _, err := clientconfighelpers.GetCLISetting(commandPrecedenceSetting, &m); err != nil {
+		return nil, errors.Wrap(err, "unable to read the command precedence")
+	}
+	if m == nil {
+		m = map[string]string{}
+	}
+	return m, nil
+}
+
+func saveCommandPrecedences(m map[string]string) error {
+	if len(m) == 0 {
+		return clientconfighelpers.DeleteCLISetting(commandPrecedenceSetting)
+	}
+	return clientconfighelpers.SetCLISetting(commandPrecedenceSetting, m)
+}
+
+// GetCommandPrecedences returns the command precedence of every command sorted by name
+func GetCommandPrecedences() ([]CommandPrecedence, error) {
+	m, err := readCommandPrecedences()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]CommandPrecedence, 0, len(m))
+	for command, winner := range m {
+		result = append(result, CommandPrecedence{Command: command, Winner: winner})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Command < result[j].Command
+	})
+	return result, nil
+}
+
+// GetCommandPrecedenceMap returns the winner of every command with a command precedence
+func GetCommandPrecedenceMap() (map[string]string, error) {
+	return readCommandPrecedences()
+}
+
+// SetCommandPrecedence selects the command to run for a command name. The winner is
+// either "core" or the target of a plugin, i.e., "global", "kubernetes" or "k8s".
+func SetCommandPrecedence(command, winner string) error {
+	if command == "" {
+		return errors.New("the command name cannot be empty")
+	}
+	if winner != CommandPrecedenceCore {
+		if !configtypes.IsValidTarget(winner, true, false) || configtypes.StringToTarget(winner) == configtypes.TargetTMC {
+			return errors.Errorf("invalid value %q, the command precedence must be one of core, global or kubernetes[k8s]", winner)
+		}
+		winner = string(configtypes.StringToTarget(winner))
+	}
+	m, err := readCommandPrecedences()
+	if err != nil {
+		return err
+	}
+	m[command] = winner
+	return saveCommandPrecedences(m)
+}
+
+// DeleteCommandPrecedence deletes the command precedence of a command name
+func DeleteCommandPrecedence(command string) error {
+	m, err := readCommandPrecedences()
+	if err != nil {
+		return err
+	}
+	if _, exists := m[command]; !exists {
+		return errors.Errorf("no command precedence for %q", command)
+	}
+	delete(m, command)
+	return saveCommandPrecedences(m)
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+)
+
+func setupCommandPrecedenceTest(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+}
+
+func TestCommandPrecedence(t *testing.T) {
+	assert := assert.New(t)
+	setupCommandPrecedenceTest(t)
+
+	precedences, err := GetCommandPrecedences()
+	assert.Nil(err)
+	assert.Empty(precedences)
+
+	assert.Nil(SetCommandPrecedence("login", CommandPrecedenceCore))
+	assert.Nil(SetCommandPrecedence("cluster", "global"))
+	assert.Nil(SetCommandPrecedence("cluster", "k8s"))
+	assert.NotNil(SetCommandPrecedence("apps", "tmc"))
+	assert.NotNil(SetCommandPrecedence("apps", "plugin"))
+	assert.NotNil(SetCommandPrecedence("", "core"))
+
+	precedences, err = GetCommandPrecedences()
+	assert.Nil(err)
+	assert.Equal([]CommandPrecedence{
+		{Command: "cluster", Winner: "kubernetes"},
+		{Command: "login", Winner: "core"},
+	}, precedences)
+
+	// The command precedence is stored in the CLI configuration
+	assert.Nil(configlib.SetEnv("FOO", "bar"))
+	m, err := GetCommandPrecedenceMap()
+	assert.Nil(err)
+	assert.Len(m, 2)
+
+	assert.Nil(DeleteCommandPrecedence("login"))
+	assert.NotNil(DeleteCommandPrecedence("login"))
+	m, err = GetCommandPrecedenceMap()
+	assert.Nil(err)
+	assert.Equal(map[string]string{"cluster": "kubernetes"}, m)
+}
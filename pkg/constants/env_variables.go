@@ -16,5 +16,84 @@ const (
 	PluginDiscoveryImageSignatureVerificationSkipList = "TANZU_CLI_PLUGIN_DISCOVERY_IMAGE_SIGNATURE_VERIFICATION_SKIP_LIST"
 	PublicKeyPathForPluginDiscoveryImageSignature     = "TANZU_CLI_PLUGIN_DISCOVERY_IMAGE_SIGNATURE_PUBLIC_KEY_PATH"
 	SuppressSkipSignatureVerificationWarning          = "TANZU_CLI_SUPPRESS_SKIP_SIGNATURE_VERIFICATION_WARNING"
-	CEIPOptInUserPromptAnswer                         = "TANZU_CLI_CEIP_OPT_IN_PROMPT_ANSWER"
+	// PluginArtifactSignatureVerificationDiscoveryList is a comma separated list of discovery image urls
+	// for which the signature of every plugin binary image must be verified before installation,
+	// regardless of the setting of their discovery source
+	PluginArtifactSignatureVerificationDiscoveryList = "TANZU_CLI_PLUGIN_ARTIFACT_SIGNATURE_VERIFICATION_DISCOVERY_LIST"
+	// ArtifactDownloadMaxRetries is the number of times a failed plugin artifact download is retried
+	ArtifactDownloadMaxRetries = "TANZU_CLI_ARTIFACT_DOWNLOAD_MAX_RETRIES"
+	// ArtifactDownloadRetryInitialBackoff is the wait time before the first retry of a plugin artifact
+	// download (e.g. 500ms, 2s). The wait time doubles before every subsequent retry.
+	ArtifactDownloadRetryInitialBackoff = "TANZU_CLI_ARTIFACT_DOWNLOAD_RETRY_INITIAL_BACKOFF"
+	// PluginDiscoveryOffline forces the use of the cached plugin inventories without
+	// contacting the discovery registries when set to true
+	PluginDiscoveryOffline = "TANZU_CLI_PLUGIN_DISCOVERY_OFFLINE"
+	// PluginDiscoveryCacheTTL is the default duration (e.g. 30m, 24h) during which the cached
+	// plugin inventory of a discovery source is used without checking for a newer inventory
+	PluginDiscoveryCacheTTL = "TANZU_CLI_PLUGIN_DISCOVERY_CACHE_TTL"
+	// PluginCatalogLockTimeout is the maximum time (e.g. 30s, 5m) to wait for another
+	// tanzu process to release the lock on the plugin catalog or the plugin directory
+	PluginCatalogLockTimeout = "TANZU_CLI_PLUGIN_CATALOG_LOCK_TIMEOUT"
+	// PluginTimeout is the maximum duration (e.g. 30m, 2h) of a plugin command.
+	// The plugin is terminated once the duration is exceeded.
+	PluginTimeout             = "TANZU_CLI_PLUGIN_TIMEOUT"
+	CEIPOptInUserPromptAnswer = "TANZU_CLI_CEIP_OPT_IN_PROMPT_ANSWER"
+	// CompletionCacheTTL is the duration (e.g. 30s, 5m) during which the shell completions
+	// obtained from a plugin are reused. Setting it to 0 disables the completion cache.
+	CompletionCacheTTL = "TANZU_CLI_COMPLETION_CACHE_TTL"
+	// ContextOverride is the name of the context to use instead of the current context
+	// of its target, without changing the current context of the configuration
+	ContextOverride = "TANZU_CONTEXT"
+	// UnmanagedPlugins set to false disables the discovery of the tanzu-<name>
+	// executables found on PATH as unmanaged plugins
+	UnmanagedPlugins = "TANZU_CLI_UNMANAGED_PLUGINS"
+	// UnmanagedPluginInfo set to true runs the "info" command of the unmanaged
+	// plugins to obtain their description
+	UnmanagedPluginInfo = "TANZU_CLI_UNMANAGED_PLUGIN_INFO"
+	// PluginAutoInstall set to true offers to install the plugin providing an unknown
+	// command, after a confirmation, and to run the command once the plugin is installed
+	PluginAutoInstall = "TANZU_CLI_PLUGIN_AUTO_INSTALL"
+	// ContextPluginSync is the action taken when plugins recommended by the active
+	// contexts are missing or outdated: prompt (default), auto, warn or off
+	ContextPluginSync = "TANZU_CLI_CONTEXT_PLUGIN_SYNC"
+	// UpdateCheck set to false disables the periodic check for newer recommended
+	// versions of the CLI and of the installed plugins
+	UpdateCheck = "TANZU_CLI_UPDATE_CHECK"
+	// UpdateCheckInterval is the minimum duration between two checks for newer
+	// recommended versions of the CLI and of the installed plugins, e.g. 12h (default 24h)
+	UpdateCheckInterval = "TANZU_CLI_UPDATE_CHECK_INTERVAL"
+)
+
+// Environment variables set by the CLI for every plugin invocation, giving the
+// plugins the same view of the active contexts as the CLI
+const (
+	// PluginEnvCLIVersion is the version of the CLI running the plugin
+	PluginEnvCLIVersion = "TANZU_CLI_VERSION"
+	// PluginEnvConfigDir is the directory of the CLI configuration files
+	PluginEnvConfigDir = "TANZU_CLI_CONFIG_DIR"
+	// PluginEnvContextK8s is the name of the active context of the kubernetes target
+	PluginEnvContextK8s = "TANZU_CLI_CONTEXT_K8S"
+	// PluginEnvContextTMC is the name of the active context of the mission-control target
+	PluginEnvContextTMC = "TANZU_CLI_CONTEXT_TMC"
+	// PluginEnvKubeconfig is the kubeconfig file of the active context of the kubernetes target
+	PluginEnvKubeconfig = "TANZU_CLI_KUBECONFIG"
+	// PluginEnvKubecontext is the kubeconfig context of the active context of the kubernetes target
+	PluginEnvKubecontext = "TANZU_CLI_KUBECONTEXT"
+	// PluginEnvEndpointK8s is the endpoint of the active context of the kubernetes target
+	PluginEnvEndpointK8s = "TANZU_CLI_ENDPOINT_K8S"
+	// PluginEnvEndpointTMC is the endpoint of the active context of the mission-control target
+	PluginEnvEndpointTMC = "TANZU_CLI_ENDPOINT_TMC"
+)
+
+// Environment variables set by the CLI for the command hooks, in addition
+// to the environment variables set for the plugins
+const (
+	// HookEnvPhase is "pre" for the hooks run before the command and "post" for the hooks run after it
+	HookEnvPhase = "TANZU_CLI_HOOK_PHASE"
+	// HookEnvPlugin is the name of the plugin running the command
+	HookEnvPlugin = "TANZU_CLI_HOOK_PLUGIN"
+	// HookEnvCommand is the command line, without the tanzu prefix
+	HookEnvCommand = "TANZU_CLI_HOOK_COMMAND"
+	// HookEnvExitCode is the exit code of the command, only set for the post-command hooks
+	HookEnvExitCode = "TANZU_CLI_HOOK_EXIT_CODE"
 )
@@ -0,0 +1,44 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cosignhelper
+
+import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// multiKeyVerifier verifies images against a list of trusted public keys
+type multiKeyVerifier struct {
+	verifiers []Cosignhelper
+}
+
+// NewMultiKeyCosignVerifier returns a verifier which considers the images
+// verified if their signature can be verified with any of the specified
+// public keys. This allows to rotate keys by trusting both the old and
+// the new key during the rotation.
+func NewMultiKeyCosignVerifier(publicKeyPaths []string) Cosignhelper {
+	if len(publicKeyPaths) == 0 {
+		return NewCosignVerifier("")
+	}
+	verifiers := make([]Cosignhelper, 0, len(publicKeyPaths))
+	for _, keyPath := range publicKeyPaths {
+		verifiers = append(verifiers, NewCosignVerifier(keyPath))
+	}
+	return &multiKeyVerifier{verifiers: verifiers}
+}
+
+// Verify verifies the signature on the images with every public key
+// until one of them succeeds
+func (mv *multiKeyVerifier) Verify(ctx context.Context, images []string) error {
+	var errs []error
+	for _, verifier := range mv.verifiers {
+		err := verifier.Verify(ctx, images)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return kerrors.NewAggregate(errs)
+}
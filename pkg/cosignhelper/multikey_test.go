@@ -0,0 +1,51 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cosignhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubVerifier struct {
+	err   error
+	calls int
+}
+
+func (sv *stubVerifier) Verify(ctx context.Context, images []string) error {
+	sv.calls++
+	return sv.err
+}
+
+func TestMultiKeyVerifier(t *testing.T) {
+	assert := assert.New(t)
+
+	oldKey := &stubVerifier{err: errors.New("old key error")}
+	newKey := &stubVerifier{}
+
+	// Any key verifying the images is enough
+	verifier := &multiKeyVerifier{verifiers: []Cosignhelper{oldKey, newKey}}
+	assert.Nil(verifier.Verify(context.Background(), []string{"image"}))
+	assert.Equal(1, oldKey.calls)
+	assert.Equal(1, newKey.calls)
+
+	// The remaining keys are not used once the images are verified
+	verifier = &multiKeyVerifier{verifiers: []Cosignhelper{newKey, oldKey}}
+	assert.Nil(verifier.Verify(context.Background(), []string{"image"}))
+	assert.Equal(1, oldKey.calls)
+
+	// All errors are reported when no key verifies the images
+	newKey.err = errors.New("new key error")
+	err := verifier.Verify(context.Background(), []string{"image"})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "old key error")
+	assert.Contains(err.Error(), "new key error")
+
+	// Without any key, the embedded public key is used
+	_, ok := NewMultiKeyCosignVerifier(nil).(*CosignVerifyOptions)
+	assert.True(ok)
+}
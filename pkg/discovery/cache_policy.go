@@ -0,0 +1,110 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/clientconfighelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// cacheTTLSetting is the setting of a discovery source, in the CLI configuration,
+// which holds the duration during which its cached inventory is used without
+// being validated against the discovery image
+const cacheTTLSetting = "cacheTTL"
+
+// SetCacheTTL sets the duration during which the cached inventory of the
+// discovery source is used without checking if the discovery image changed.
+// A TTL of 0 validates the cache on every use.
+func SetCacheTTL(discoveryName string, ttl time.Duration) error {
+	if ttl < 0 {
+		return errors.Errorf("invalid cache TTL %v, it cannot be negative", ttl)
+	}
+	return clientconfighelpers.SetDiscoverySourceSetting(discoveryName, cacheTTLSetting, ttl.String())
+}
+
+// DeleteCacheTTL removes the cache TTL configured for the discovery source
+func DeleteCacheTTL(discoveryName string) error {
+	return clientconfighelpers.DeleteDiscoverySourceSetting(discoveryName, cacheTTLSetting)
+}
+
+// GetCacheTTL returns the cache TTL of the discovery source.
+// If no TTL is configured for the discovery source, the TTL configured through
+// the TANZU_CLI_PLUGIN_DISCOVERY_CACHE_TTL variable is returned, if any.
+// Otherwise 0 is returned and the cache is validated on every use.
+func GetCacheTTL(discoveryName string) (time.Duration, error) {
+	var value string
+	found, err := clientconfighelpers.GetDiscoverySourceSetting(discoveryName, cacheTTLSetting, &value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to read the cache TTL of discovery source '%s'", discoveryName)
+	}
+	if found {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return 0, errors.Errorf("invalid cache TTL %q for discovery source '%s'", value, discoveryName)
+		}
+		return ttl, nil
+	}
+	if value := os.Getenv(constants.PluginDiscoveryCacheTTL); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return 0, errors.Errorf("invalid value %q for %s", value, constants.PluginDiscoveryCacheTTL)
+		}
+		return ttl, nil
+	}
+	return 0, nil
+}
+
+// isCacheWithinTTL returns true if the cached inventory of the discovery can
+// be used without checking if the discovery image changed
+func (od *DBBackedOCIDiscovery) isCacheWithinTTL() bool {
+	ttl, err := GetCacheTTL(od.Name())
+	if err != nil {
+		log.Warningf("%v, the cached plugin inventory will be validated", err)
+		return false
+	}
+	if ttl == 0 {
+		return false
+	}
+	_, lastVerified, err := od.cachedInventoryInfo()
+	if err != nil {
+		return false
+	}
+	return time.Since(lastVerified) < ttl
+}
+
+// CacheAge returns the time elapsed since the cached inventory of
+// the discovery was last verified to match the discovery image
+func (od *DBBackedOCIDiscovery) CacheAge() (time.Duration, error) {
+	_, lastVerified, err := od.cachedInventoryInfo()
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(lastVerified), nil
+}
+
+// RefreshCache downloads the inventory of the discovery again
+// regardless of the cached inventory
+func (od *DBBackedOCIDiscovery) RefreshCache() error {
+	if IsOfflineModeForced() {
+		return errors.Errorf("unable to refresh the plugin inventory of discovery '%s' in offline mode", od.Name())
+	}
+	// Removing the digest file invalidates the cache
+	matches, _ := filepath.Glob(filepath.Join(od.pluginDataDir, "digest.*"))
+	for _, filePath := range matches {
+		if err := os.Remove(filePath); err != nil {
+			return errors.Wrapf(err, "unable to invalidate the cached plugin inventory of discovery '%s'", od.Name())
+		}
+	}
+	if err := od.fetchInventoryImage(); err != nil {
+		return errors.Wrapf(err, "unable to refresh the plugin inventory of discovery '%s'", od.Name())
+	}
+	return nil
+}
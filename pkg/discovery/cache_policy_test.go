@@ -0,0 +1,107 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+func TestCacheTTL(t *testing.T) {
+	assert := assert.New(t)
+	defer setupCLIConfigTest(t, "default", "other")()
+
+	// A TTL can only be set for a configured discovery source
+	assert.NotNil(SetCacheTTL("unknown", time.Hour))
+
+	ttl, err := GetCacheTTL("default")
+	assert.Nil(err)
+	assert.Equal(time.Duration(0), ttl)
+
+	os.Setenv(constants.PluginDiscoveryCacheTTL, "1h")
+	defer os.Unsetenv(constants.PluginDiscoveryCacheTTL)
+	ttl, err = GetCacheTTL("default")
+	assert.Nil(err)
+	assert.Equal(time.Hour, ttl)
+
+	// The TTL of the discovery source has precedence over the default TTL
+	assert.Nil(SetCacheTTL("default", 24*time.Hour))
+	assert.Nil(SetCacheTTL("other", 0))
+	ttl, err = GetCacheTTL("default")
+	assert.Nil(err)
+	assert.Equal(24*time.Hour, ttl)
+	ttl, err = GetCacheTTL("other")
+	assert.Nil(err)
+	assert.Equal(time.Duration(0), ttl)
+
+	assert.Nil(DeleteCacheTTL("default"))
+	ttl, err = GetCacheTTL("default")
+	assert.Nil(err)
+	assert.Equal(time.Hour, ttl)
+
+	assert.NotNil(SetCacheTTL("default", -time.Minute))
+
+	os.Setenv(constants.PluginDiscoveryCacheTTL, "invalid")
+	_, err = GetCacheTTL("default")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), constants.PluginDiscoveryCacheTTL)
+}
+
+func TestIsCacheWithinTTL(t *testing.T) {
+	assert := assert.New(t)
+	defer setupCLIConfigTest(t, "test-discovery")()
+
+	dataDir, err := os.MkdirTemp("", "cache_ttl")
+	assert.Nil(err)
+	defer os.RemoveAll(dataDir)
+
+	od := &DBBackedOCIDiscovery{name: "test-discovery", image: "example.com/plugins/plugin-inventory:latest", pluginDataDir: dataDir}
+
+	// Nothing is cached
+	assert.False(od.isCacheWithinTTL())
+	_, err = od.CacheAge()
+	assert.NotNil(err)
+
+	digestFile := filepath.Join(dataDir, "digest.1234")
+	assert.Nil(os.WriteFile(filepath.Join(dataDir, plugininventory.SQliteDBFileName), []byte{}, 0o600))
+	assert.Nil(os.WriteFile(digestFile, []byte(od.image), 0o600))
+	lastVerified := time.Now().Add(-2 * time.Hour)
+	assert.Nil(os.Chtimes(digestFile, lastVerified, lastVerified))
+
+	age, err := od.CacheAge()
+	assert.Nil(err)
+	assert.InDelta(2*time.Hour, age, float64(time.Minute))
+
+	// No TTL is configured
+	assert.False(od.isCacheWithinTTL())
+
+	assert.Nil(SetCacheTTL(od.name, 3*time.Hour))
+	assert.True(od.isCacheWithinTTL())
+
+	assert.Nil(SetCacheTTL(od.name, time.Hour))
+	assert.False(od.isCacheWithinTTL())
+
+	// The cache was created for another image of the discovery
+	assert.Nil(SetCacheTTL(od.name, 3*time.Hour))
+	assert.Nil(os.WriteFile(digestFile, []byte("example.com/plugins/plugin-inventory:v1"), 0o600))
+	assert.Nil(os.Chtimes(digestFile, lastVerified, lastVerified))
+	assert.False(od.isCacheWithinTTL())
+}
+
+func TestRefreshCacheInOfflineMode(t *testing.T) {
+	os.Setenv(constants.PluginDiscoveryOffline, "true")
+	defer os.Unsetenv(constants.PluginDiscoveryOffline)
+
+	od := &DBBackedOCIDiscovery{name: "test-discovery", image: "example.com/plugins/plugin-inventory:latest"}
+	err := od.RefreshCache()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "offline mode")
+}
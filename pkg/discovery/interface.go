@@ -10,6 +10,7 @@ package discovery
 
 import (
 	"errors"
+	"time"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
@@ -27,6 +28,32 @@ type Discovery interface {
 	Type() string
 }
 
+// CachedDiscovery is a discovery which stores its inventory in a local cache
+type CachedDiscovery interface {
+	Discovery
+
+	// CacheAge returns the time elapsed since the cache was last
+	// verified to be up-to-date
+	CacheAge() (time.Duration, error)
+
+	// RefreshCache updates the cache regardless of its age
+	RefreshCache() error
+}
+
+// ErrSignatureVerificationSkipped is returned when verifying a discovery source
+// whose signature verification has been disabled by the user
+var ErrSignatureVerificationSkipped = errors.New("signature verification is skipped")
+
+// VerifiableDiscovery is a discovery whose source can be verified
+// without fetching its content
+type VerifiableDiscovery interface {
+	Discovery
+
+	// VerifySource checks that the source of the discovery is reachable
+	// and that its signature can be verified
+	VerifySource() error
+}
+
 type GroupDiscovery interface {
 	// GetAllGroups returns all plugin groups defined in the discovery
 	GetAllGroups() ([]*plugininventory.PluginGroup, error)
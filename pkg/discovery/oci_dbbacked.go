@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
@@ -101,8 +103,18 @@ func (od *DBBackedOCIDiscovery) listPluginsFromInventory() ([]Discovered, error)
 		}
 	}
 
+	artifactVerifier, err := od.pluginArtifactSignatureVerifier()
+	if err != nil {
+		return nil, err
+	}
+
 	var discoveredPlugins []Discovered
 	for _, entry := range pluginEntries {
+		artifacts := entry.Artifacts
+		if artifactVerifier != nil {
+			artifacts = withSignatureVerifier(entry.Artifacts, artifactVerifier)
+		}
+
 		// First build the sorted list of versions from the Artifacts map
 		var versions []string
 		for v := range entry.Artifacts {
@@ -118,7 +130,7 @@ func (od *DBBackedOCIDiscovery) listPluginsFromInventory() ([]Discovered, error)
 			RecommendedVersion: entry.RecommendedVersion,
 			InstalledVersion:   "", // Not set when discovered, but later.
 			SupportedVersions:  versions,
-			Distribution:       entry.Artifacts,
+			Distribution:       artifacts,
 			Optional:           false,
 			Scope:              common.PluginScopeStandalone,
 			Source:             od.name,
@@ -138,8 +150,28 @@ func (od *DBBackedOCIDiscovery) listGroupsFromInventory() ([]*plugininventory.Pl
 
 // fetchInventoryImage downloads the OCI image containing the information about the
 // inventory of this discovery and stores it in the cache directory.
+// The cached inventory is used without contacting the registry while it is
+// within the cache TTL of the discovery. When offline mode is forced, or when
+// the registry cannot be reached, the cached inventory is used as well.
 func (od *DBBackedOCIDiscovery) fetchInventoryImage() error {
-	newCacheHashFile := od.checkImageCache()
+	if IsOfflineModeForced() {
+		return od.useCachedInventory()
+	}
+	if od.isCacheWithinTTL() {
+		// The cache is trusted for the configured TTL.  We are done.
+		return nil
+	}
+
+	newCacheHashFile, err := od.checkImageCache()
+	if err != nil {
+		if isNetworkError(err) {
+			log.Warningf("Unable to reach the plugin discovery image %q: %v", od.image, err)
+			if cacheErr := od.useCachedInventory(); cacheErr == nil {
+				return nil
+			}
+		}
+		return err
+	}
 	if newCacheHashFile == "" {
 		// The cache can be re-used.  We are done.
 		return nil
@@ -148,23 +180,26 @@ func (od *DBBackedOCIDiscovery) fetchInventoryImage() error {
 	// The DB has changed and needs to be updated in the cache.
 	log.Infof("Reading plugin inventory for %q, this will take a few seconds.", od.image)
 
-	// Get the custom public key path and prepare cosign verifier, if empty, cosign verifier would use embedded public key for verification
-	customPublicKeyPath := os.Getenv(constants.PublicKeyPathForPluginDiscoveryImageSignature)
-	cosignVerifier := cosignhelper.NewCosignVerifier(customPublicKeyPath)
+	// Prepare the cosign verifier with the public keys trusted for this discovery image,
+	// if none is configured, cosign verifier would use embedded public key for verification
+	cosignVerifier, err := od.signatureVerifier()
+	if err != nil {
+		return err
+	}
 	if sigVerifyErr := od.verifyInventoryImageSignature(cosignVerifier); sigVerifyErr != nil {
 		log.Warningf("Unable to verify the plugins discovery image signature: %v", sigVerifyErr)
 		// TODO(pkalle): Update the message to convey user to check if they could use the latest public key after we get details of the well known location of the public key
-		errMsg := fmt.Sprintf("Fatal, plugins discovery image signature verification failed. The `tanzu` CLI can not ensure the integrity of the plugins to be installed. To ignore this validation please append %q to the comma-separated list in the environment variable %q.  This is NOT RECOMMENDED and could put your environment at risk!",
+		return errors.Errorf("plugins discovery image signature verification failed. The `tanzu` CLI can not ensure the integrity of the plugins to be installed. To ignore this validation please append %q to the comma-separated list in the environment variable %q.  This is NOT RECOMMENDED and could put your environment at risk!",
 			od.image, constants.PluginDiscoveryImageSignatureVerificationSkipList)
-		log.Fatal(nil, errMsg)
 	}
 
 	if err := carvelhelpers.DownloadImageAndSaveFilesToDir(od.image, od.pluginDataDir); err != nil {
 		return errors.Wrapf(err, "failed to download OCI image from discovery '%s'", od.Name())
 	}
 
-	// Now that everything is ready, create the digest hash file
-	_, _ = os.Create(newCacheHashFile)
+	// Now that everything is ready, create the digest hash file.
+	// It records the image the cache was created for.
+	_ = os.WriteFile(newCacheHashFile, []byte(od.image), 0o644)
 
 	return nil
 }
@@ -174,16 +209,18 @@ func (od *DBBackedOCIDiscovery) fetchInventoryImage() error {
 // It returns an empty string if the cache can be used.  Otherwise
 // it returns the name of the digest file that must be created once
 // the new DB image has been downloaded.
-func (od *DBBackedOCIDiscovery) checkImageCache() string {
+func (od *DBBackedOCIDiscovery) checkImageCache() (string, error) {
 	// Get the latest digest of the discovery image.
 	// If the cache already contains the image with this digest
 	// we do not need to verify its signature nor to download it again.
 	_, hashHexVal, err := carvelhelpers.GetImageDigest(od.image)
 	if err != nil {
-		// This will happen when the user has configured an invalid image discovery URI
-		log.Warningf("Unable to resolve the plugin discovery image: %v", err)
-		// We force abort execution here to make sure a stale image left in the cache is not used by mistake.
-		log.Fatal(nil, fmt.Sprintf("Fatal: plugins discovery image resolution failed. Please check that the repository image URL %q is correct ", od.image))
+		if isNetworkError(err) {
+			return "", err
+		}
+		// This will happen when the user has configured an invalid image discovery URI.
+		// We return an error to make sure a stale image left in the cache is not used by mistake.
+		return "", errors.Wrapf(err, "plugins discovery image resolution failed. Please check that the repository image URL %q is correct", od.image)
 	}
 
 	// We store the digest hash of the cached DB as a file named "digest.<hash>.
@@ -203,18 +240,41 @@ func (od *DBBackedOCIDiscovery) checkImageCache() string {
 	} else if len(matches) == 1 {
 		if matches[0] == correctHashFile {
 			// The hash file exists which means the DB is up-to-date.  We are done.
-			return ""
+			// Record when the cache was last verified to be up-to-date.
+			now := time.Now()
+			_ = os.Chtimes(correctHashFile, now, now)
+			return "", nil
 		}
 		// The hash file indicates a different digest hash. Remove this old hash file
 		// as we will download the new DB.
 		os.Remove(matches[0])
 	}
-	return correctHashFile
+	return correctHashFile, nil
+}
+
+// VerifySource checks that the discovery image can be resolved and that its
+// signature can be verified with the public keys trusted for the image.
+// It returns ErrSignatureVerificationSkipped if the image is part of the
+// signature verification skip list.
+func (od *DBBackedOCIDiscovery) VerifySource() error {
+	if _, _, err := carvelhelpers.GetImageDigest(od.image); err != nil {
+		return errors.Wrapf(err, "unable to resolve the discovery image %q", od.image)
+	}
+	if isSignatureVerificationSkipped(od.image) {
+		return ErrSignatureVerificationSkipped
+	}
+	verifier, err := od.signatureVerifier()
+	if err != nil {
+		return err
+	}
+	if err := verifier.Verify(context.Background(), []string{od.image}); err != nil {
+		return errors.Wrapf(err, "unable to verify the signature of the discovery image %q", od.image)
+	}
+	return nil
 }
 
 func (od *DBBackedOCIDiscovery) verifyInventoryImageSignature(verifier cosignhelper.Cosignhelper) error {
-	signatureVerificationSkipSet := getPluginDiscoveryImagesSkippedForSignatureVerification()
-	if _, exists := signatureVerificationSkipSet[strings.TrimSpace(od.image)]; exists {
+	if isSignatureVerificationSkipped(od.image) {
 		// log warning message iff user had not chosen to skip warning message for signature verification
 		if skip, _ := strconv.ParseBool(os.Getenv(constants.SuppressSkipSignatureVerificationWarning)); !skip {
 			log.Warningf("Skipping the plugins discovery image signature verification for %q\n ", od.image)
@@ -229,9 +289,82 @@ func (od *DBBackedOCIDiscovery) verifyInventoryImageSignature(verifier cosignhel
 	return nil
 }
 
-func getPluginDiscoveryImagesSkippedForSignatureVerification() map[string]struct{} {
+// pluginArtifactSignatureVerifier returns the verifier to use for the plugin binary
+// images of this discovery, or nil if their signature should not be verified
+func (od *DBBackedOCIDiscovery) pluginArtifactSignatureVerifier() (cosignhelper.Cosignhelper, error) {
+	enabled, err := IsPluginSignatureVerificationEnabled(od.name, od.image)
+	if err != nil || !enabled {
+		return nil, err
+	}
+	verifier, err := od.signatureVerifier()
+	if err != nil {
+		return nil, err
+	}
+	return &pluginArtifactVerifier{
+		discoveryImage: od.image,
+		verifier:       verifier,
+	}, nil
+}
+
+// signatureVerifier returns the verifier for the images of this discovery
+// using the public keys trusted for the discovery image
+func (od *DBBackedOCIDiscovery) signatureVerifier() (cosignhelper.Cosignhelper, error) {
+	publicKeys, err := GetTrustedPublicKeys(od.image)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get the trusted public keys for %q", od.image)
+	}
+	return cosignhelper.NewMultiKeyCosignVerifier(publicKeys), nil
+}
+
+// pluginArtifactVerifier verifies the signature of the plugin binary images of
+// a discovery while honoring the signature verification skip list of the discovery
+type pluginArtifactVerifier struct {
+	discoveryImage string
+	verifier       cosignhelper.Cosignhelper
+}
+
+// Verify verifies the signature on the plugin images
+func (v *pluginArtifactVerifier) Verify(ctx context.Context, images []string) error {
+	if isSignatureVerificationSkipped(v.discoveryImage) {
+		// log warning message iff user had not chosen to skip warning message for signature verification
+		if skip, _ := strconv.ParseBool(os.Getenv(constants.SuppressSkipSignatureVerificationWarning)); !skip {
+			log.Warningf("Skipping the plugin image signature verification for %v\n ", images)
+		}
+		return nil
+	}
+
+	if err := v.verifier.Verify(ctx, images); err != nil {
+		return errors.Wrapf(err, "the `tanzu` CLI can not ensure the integrity of the plugin to be installed. To ignore this validation please append %q to the comma-separated list in the environment variable %q.  This is NOT RECOMMENDED and could put your environment at risk!",
+			v.discoveryImage, constants.PluginDiscoveryImageSignatureVerificationSkipList)
+	}
+	return nil
+}
+
+// withSignatureVerifier returns a copy of the artifacts configured to
+// use the specified verifier before fetching a plugin binary
+func withSignatureVerifier(artifacts distribution.Artifacts, verifier cosignhelper.Cosignhelper) distribution.Artifacts {
+	verifiedArtifacts := make(distribution.Artifacts, len(artifacts))
+	for version, artifactList := range artifacts {
+		verifiedList := make(distribution.ArtifactList, len(artifactList))
+		for i := range artifactList {
+			verifiedList[i] = artifactList[i]
+			verifiedList[i].SignatureVerifier = verifier
+		}
+		verifiedArtifacts[version] = verifiedList
+	}
+	return verifiedArtifacts
+}
+
+func isSignatureVerificationSkipped(discoveryImage string) bool {
+	_, exists := getDiscoveryImagesFromEnv(constants.PluginDiscoveryImageSignatureVerificationSkipList)[strings.TrimSpace(discoveryImage)]
+	return exists
+}
+
+// getDiscoveryImagesFromEnv returns the set of discovery images specified as
+// a comma separated list in the specified environment variable
+func getDiscoveryImagesFromEnv(envVariable string) map[string]struct{} {
 	discoveryImages := map[string]struct{}{}
-	discoveryImagesList := strings.Split(os.Getenv(constants.PluginDiscoveryImageSignatureVerificationSkipList), ",")
+	discoveryImagesList := strings.Split(os.Getenv(envVariable), ",")
 	for _, image := range discoveryImagesList {
 		image = strings.TrimSpace(image)
 		if image != "" {
@@ -4,11 +4,16 @@
 package discovery
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
@@ -16,6 +21,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 )
 
@@ -380,6 +386,12 @@ func (stub *stubInventory) UpdatePluginActivationState(pluginInventoryEntry *plu
 func (stub *stubInventory) UpdatePluginGroupActivationState(pg *plugininventory.PluginGroup) error {
 	return nil
 }
+func (stub *stubInventory) GetCLIRecommendedVersion() (string, error) {
+	return "", nil
+}
+func (stub *stubInventory) SetCLIRecommendedVersion(version string) error {
+	return nil
+}
 
 var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 	var (
@@ -599,6 +611,263 @@ var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 			})
 		})
 	})
+
+	Describe("Verify plugin artifact signature", func() {
+		var (
+			cosignVerifier *fakes.Cosignhelperfake
+			dbDiscovery    *DBBackedOCIDiscovery
+			ok             bool
+		)
+		BeforeEach(func() {
+			tmpDir, err = os.MkdirTemp(os.TempDir(), "")
+			Expect(err).To(BeNil(), "unable to create temporary directory")
+
+			tkgConfigFile, err = os.CreateTemp("", "config")
+			Expect(err).To(BeNil())
+			os.Setenv("TANZU_CONFIG", tkgConfigFile.Name())
+
+			tkgConfigFileNG, err = os.CreateTemp("", "config_ng")
+			Expect(err).To(BeNil())
+			os.Setenv("TANZU_CONFIG_NEXT_GEN", tkgConfigFileNG.Name())
+
+			discovery = NewOCIDiscovery("test-discovery", "test-image:latest", nil)
+			dbDiscovery, ok = discovery.(*DBBackedOCIDiscovery)
+			Expect(ok).To(BeTrue(), "oci discovery is not of type DBBackedOCIDiscovery")
+			dbDiscovery.pluginDataDir = tmpDir
+			dbDiscovery.inventory = &stubInventory{}
+		})
+		AfterEach(func() {
+			os.Unsetenv(constants.PluginArtifactSignatureVerificationDiscoveryList)
+			os.Unsetenv(constants.PluginDiscoveryImageSignatureVerificationSkipList)
+			os.Unsetenv("TANZU_CONFIG")
+			os.Unsetenv("TANZU_CONFIG_NEXT_GEN")
+			os.RemoveAll(tkgConfigFile.Name())
+			os.RemoveAll(tkgConfigFileNG.Name())
+			os.RemoveAll(tmpDir)
+		})
+		Context("When the discovery is not configured for plugin artifact verification", func() {
+			It("should not set any signature verifier on the artifacts", func() {
+				plugins, err := dbDiscovery.listPluginsFromInventory()
+				Expect(err).To(BeNil())
+				for _, p := range plugins {
+					for _, artifacts := range p.Distribution.(distribution.Artifacts) {
+						for _, a := range artifacts {
+							Expect(a.SignatureVerifier).To(BeNil())
+						}
+					}
+				}
+			})
+		})
+		Context("When the discovery is configured for plugin artifact verification", func() {
+			It("should set a signature verifier on every artifact", func() {
+				os.Setenv(constants.PluginArtifactSignatureVerificationDiscoveryList, "other-image:latest,"+dbDiscovery.image)
+				plugins, err := dbDiscovery.listPluginsFromInventory()
+				Expect(err).To(BeNil())
+				Expect(len(plugins)).To(Equal(len(pluginEntries)))
+				for _, p := range plugins {
+					for _, artifacts := range p.Distribution.(distribution.Artifacts) {
+						for _, a := range artifacts {
+							Expect(a.SignatureVerifier).ToNot(BeNil())
+						}
+					}
+				}
+				// The inventory entries must not be modified
+				for _, entry := range pluginEntries {
+					for _, artifacts := range entry.Artifacts {
+						for _, a := range artifacts {
+							Expect(a.SignatureVerifier).To(BeNil())
+						}
+					}
+				}
+			})
+		})
+		Context("When the discovery source is configured for plugin artifact verification", func() {
+			It("should set a signature verifier on every artifact", func() {
+				Expect(configlib.SetCLIDiscoverySource(configtypes.PluginDiscovery{
+					OCI: &configtypes.OCIDiscovery{Name: "test-discovery", Image: "test-image:latest"},
+				})).To(Succeed())
+				Expect(SetVerifyPluginSignatures("test-discovery", true)).To(Succeed())
+				plugins, err := dbDiscovery.listPluginsFromInventory()
+				Expect(err).To(BeNil())
+				Expect(len(plugins)).To(Equal(len(pluginEntries)))
+				for _, p := range plugins {
+					for _, artifacts := range p.Distribution.(distribution.Artifacts) {
+						for _, a := range artifacts {
+							Expect(a.SignatureVerifier).ToNot(BeNil())
+						}
+					}
+				}
+			})
+		})
+		Context("When the plugin artifact signature verification fails", func() {
+			It("should return an error unless the discovery is in the skip list", func() {
+				cosignVerifier = &fakes.Cosignhelperfake{}
+				cosignVerifier.VerifyReturns(fmt.Errorf("signature verification fake error"))
+				verifier := &pluginArtifactVerifier{discoveryImage: dbDiscovery.image, verifier: cosignVerifier}
+
+				err = verifier.Verify(context.Background(), []string{"test-plugin:v1.0.0"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("signature verification fake error"))
+				Expect(err.Error()).To(ContainSubstring(constants.PluginDiscoveryImageSignatureVerificationSkipList))
+
+				os.Setenv(constants.PluginDiscoveryImageSignatureVerificationSkipList, dbDiscovery.image)
+				err = verifier.Verify(context.Background(), []string{"test-plugin:v1.0.0"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cosignVerifier.VerifyCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("Offline mode", func() {
+		var dbDiscovery *DBBackedOCIDiscovery
+		BeforeEach(func() {
+			tmpDir, err = os.MkdirTemp(os.TempDir(), "")
+			Expect(err).To(BeNil(), "unable to create temporary directory")
+
+			discovery = NewOCIDiscovery("test-discovery", "test-image:latest", nil)
+			var ok bool
+			dbDiscovery, ok = discovery.(*DBBackedOCIDiscovery)
+			Expect(ok).To(BeTrue(), "oci discovery is not of type DBBackedOCIDiscovery")
+			dbDiscovery.pluginDataDir = tmpDir
+			dbDiscovery.inventory = &stubInventory{}
+
+			os.Setenv(constants.PluginDiscoveryOffline, "true")
+		})
+		AfterEach(func() {
+			os.Unsetenv(constants.PluginDiscoveryOffline)
+			os.RemoveAll(tmpDir)
+		})
+		Context("When the inventory was never cached", func() {
+			It("should return an error", func() {
+				_, err = dbDiscovery.List()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("no cached plugin inventory is available for discovery 'test-discovery'"))
+			})
+		})
+		Context("When the inventory is cached", func() {
+			It("should list the plugins of the cached inventory without contacting the registry", func() {
+				Expect(os.WriteFile(filepath.Join(tmpDir, plugininventory.SQliteDBFileName), []byte{}, 0o600)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(tmpDir, "digest.1234"), []byte("test-image:latest"), 0o600)).To(Succeed())
+				lastVerified := time.Now().Add(-48 * time.Hour)
+				Expect(os.Chtimes(filepath.Join(tmpDir, "digest.1234"), lastVerified, lastVerified)).To(Succeed())
+
+				_, cacheTime, err := dbDiscovery.cachedInventoryInfo()
+				Expect(err).To(BeNil())
+				Expect(cacheTime.Unix()).To(Equal(lastVerified.Unix()))
+
+				plugins, err := dbDiscovery.List()
+				Expect(err).To(BeNil())
+				Expect(len(plugins)).To(Equal(len(pluginEntries)))
+			})
+		})
+		Context("When the inventory was cached for another image", func() {
+			It("should return an error", func() {
+				Expect(os.WriteFile(filepath.Join(tmpDir, plugininventory.SQliteDBFileName), []byte{}, 0o600)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(tmpDir, "digest.1234"), []byte("old-image:latest"), 0o600)).To(Succeed())
+
+				_, err = dbDiscovery.List()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`no cached plugin inventory is available for the image "test-image:latest" of discovery 'test-discovery'`))
+			})
+		})
+	})
+
+	Describe("List cached plugins", func() {
+		var originalCacheDir string
+		BeforeEach(func() {
+			tmpDir, err = os.MkdirTemp(os.TempDir(), "")
+			Expect(err).To(BeNil(), "unable to create temporary directory")
+			originalCacheDir = common.DefaultCacheDir
+			common.DefaultCacheDir = tmpDir
+		})
+		AfterEach(func() {
+			common.DefaultCacheDir = originalCacheDir
+			os.RemoveAll(tmpDir)
+		})
+		Context("When the inventory was never cached", func() {
+			It("should return an error", func() {
+				_, err = ListCachedPlugins("test-discovery", "test-image:latest")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("no cached plugin inventory is available for discovery 'test-discovery'"))
+			})
+		})
+		Context("When the inventory is cached", func() {
+			It("should list the plugins and their recommended version", func() {
+				dataDir := filepath.Join(tmpDir, inventoryDirName, "test-discovery")
+				Expect(os.MkdirAll(dataDir, 0o755)).To(Succeed())
+				inventory := plugininventory.NewSQLiteInventory(filepath.Join(dataDir, plugininventory.SQliteDBFileName), "")
+				Expect(inventory.CreateSchema()).To(Succeed())
+				for _, entry := range pluginEntries {
+					Expect(inventory.InsertPlugin(entry)).To(Succeed())
+				}
+				Expect(os.WriteFile(filepath.Join(dataDir, "digest.1234"), []byte("test-image:latest"), 0o600)).To(Succeed())
+
+				cachedEntries, err := inventory.GetAllPlugins()
+				Expect(err).To(BeNil())
+
+				plugins, err := ListCachedPlugins("test-discovery", "test-image:latest")
+				Expect(err).To(BeNil())
+				Expect(len(plugins)).To(Equal(len(pluginEntries)))
+				for _, p := range plugins {
+					entry := findMatchingPluginEntry(cachedEntries, p.Name, p.Target)
+					Expect(entry).ToNot(BeNil())
+					Expect(p.RecommendedVersion).To(Equal(entry.RecommendedVersion))
+					Expect(p.Source).To(Equal("test-discovery"))
+				}
+			})
+		})
+	})
+
+	Describe("Get the cached recommended CLI version", func() {
+		var originalCacheDir string
+		BeforeEach(func() {
+			tmpDir, err = os.MkdirTemp(os.TempDir(), "")
+			Expect(err).To(BeNil(), "unable to create temporary directory")
+			originalCacheDir = common.DefaultCacheDir
+			common.DefaultCacheDir = tmpDir
+		})
+		AfterEach(func() {
+			common.DefaultCacheDir = originalCacheDir
+			os.RemoveAll(tmpDir)
+		})
+		Context("When the inventory was never cached", func() {
+			It("should return an error", func() {
+				_, err = GetCachedCLIRecommendedVersion("test-discovery", "test-image:latest")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("no cached plugin inventory is available for discovery 'test-discovery'"))
+			})
+		})
+		Context("When the inventory is cached", func() {
+			var inventory plugininventory.PluginInventory
+			BeforeEach(func() {
+				dataDir := filepath.Join(tmpDir, inventoryDirName, "test-discovery")
+				Expect(os.MkdirAll(dataDir, 0o755)).To(Succeed())
+				inventory = plugininventory.NewSQLiteInventory(filepath.Join(dataDir, plugininventory.SQliteDBFileName), "")
+				Expect(inventory.CreateSchema()).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(dataDir, "digest.1234"), []byte("test-image:latest"), 0o600)).To(Succeed())
+			})
+			It("should return an empty version if the inventory does not recommend a version", func() {
+				version, err := GetCachedCLIRecommendedVersion("test-discovery", "test-image:latest")
+				Expect(err).To(BeNil())
+				Expect(version).To(BeEmpty())
+			})
+			It("should return the recommended version", func() {
+				Expect(inventory.SetCLIRecommendedVersion("v1.2.0")).To(Succeed())
+				version, err := GetCachedCLIRecommendedVersion("test-discovery", "test-image:latest")
+				Expect(err).To(BeNil())
+				Expect(version).To(Equal("v1.2.0"))
+			})
+		})
+	})
+
+	Describe("Detect network errors", func() {
+		It("should only consider errors caused by an unreachable registry", func() {
+			Expect(isNetworkError(nil)).To(BeFalse())
+			Expect(isNetworkError(errors.New("MANIFEST_UNKNOWN: manifest unknown"))).To(BeFalse())
+			Expect(isNetworkError(errors.Wrap(&net.DNSError{Err: "no such host", Name: "example.com"}, "error getting the image digest"))).To(BeTrue())
+			Expect(isNetworkError(errors.New(`Get "https://example.com/v2/": dial tcp 10.0.0.1:443: connect: connection refused`))).To(BeTrue())
+		})
+	})
 })
 
 func getSupportedVersions(artifacts distribution.Artifacts) []string {
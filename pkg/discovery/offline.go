@@ -0,0 +1,153 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// IsOfflineModeForced returns true if the CLI must use the cached plugin
+// inventories without contacting the discovery registries
+func IsOfflineModeForced() bool {
+	offline, _ := strconv.ParseBool(os.Getenv(constants.PluginDiscoveryOffline))
+	return offline
+}
+
+// networkErrorMessages are the messages of the errors returned when a registry
+// cannot be reached. They are used when the error chain does not allow to
+// find the original network error.
+var networkErrorMessages = []string{
+	"dial tcp",
+	"no such host",
+	"connection refused",
+	"connection reset",
+	"network is unreachable",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"Client.Timeout exceeded",
+}
+
+// isNetworkError returns true if the error was caused by the registry
+// being unreachable
+func isNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	for _, msg := range networkErrorMessages {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// cachedInventoryInfo returns the digest file of the cached inventory of the
+// discovery and the last time the cached inventory was verified to match the
+// discovery image. It returns an error if there is no usable cached inventory,
+// including when the cached inventory was downloaded for another image, e.g.,
+// before the image of the discovery source was changed.
+func (od *DBBackedOCIDiscovery) cachedInventoryInfo() (string, time.Time, error) {
+	matches, _ := filepath.Glob(filepath.Join(od.pluginDataDir, "digest.*"))
+	if len(matches) != 1 {
+		return "", time.Time{}, errors.Errorf("no cached plugin inventory is available for discovery '%s'", od.Name())
+	}
+	if _, err := os.Stat(filepath.Join(od.pluginDataDir, plugininventory.SQliteDBFileName)); err != nil {
+		return "", time.Time{}, errors.Errorf("no cached plugin inventory is available for discovery '%s'", od.Name())
+	}
+	// The digest file holds the image the cached inventory was downloaded from
+	if b, err := os.ReadFile(matches[0]); err != nil || string(b) != od.image {
+		return "", time.Time{}, errors.Errorf("no cached plugin inventory is available for the image %q of discovery '%s'", od.image, od.Name())
+	}
+	info, err := os.Stat(matches[0])
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "unable to read the cached plugin inventory of discovery '%s'", od.Name())
+	}
+	return matches[0], info.ModTime(), nil
+}
+
+// useCachedInventory checks that the cached inventory of the discovery can be
+// used without contacting the registry and warns the user that it may be stale
+func (od *DBBackedOCIDiscovery) useCachedInventory() error {
+	_, lastVerified, err := od.cachedInventoryInfo()
+	if err != nil {
+		return err
+	}
+	log.Warningf("Using the cached plugin inventory for %q which was last refreshed %s ago (%s). It may not contain the latest plugins.",
+		od.image, time.Since(lastVerified).Round(time.Second), lastVerified.Format(time.RFC3339))
+	return nil
+}
+
+// newCachedOCIDiscovery returns the OCI discovery with the specified name and
+// image if it has a usable cached inventory
+func newCachedOCIDiscovery(name, image string) (*DBBackedOCIDiscovery, error) {
+	od, ok := NewOCIDiscovery(name, image, nil).(*DBBackedOCIDiscovery)
+	if !ok {
+		return nil, errors.Errorf("discovery '%s' does not use a cached plugin inventory", name)
+	}
+	if _, _, err := od.cachedInventoryInfo(); err != nil {
+		return nil, err
+	}
+	return od, nil
+}
+
+// ListCachedPlugins returns the plugins found in the cached inventory of the
+// OCI discovery with the specified name and image. The registry is never
+// contacted: an error is returned if there is no usable cached inventory.
+func ListCachedPlugins(name, image string) ([]Discovered, error) {
+	od, err := newCachedOCIDiscovery(name, image)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := od.getInventory().GetAllPlugins()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the cached plugin inventory of discovery '%s'", name)
+	}
+	plugins := make([]Discovered, 0, len(entries))
+	for _, entry := range entries {
+		plugins = append(plugins, Discovered{
+			Name:               entry.Name,
+			Description:        entry.Description,
+			RecommendedVersion: entry.RecommendedVersion,
+			Scope:              common.PluginScopeStandalone,
+			Source:             od.name,
+			DiscoveryType:      common.DiscoveryTypeOCI,
+			Target:             entry.Target,
+			Status:             common.PluginStatusNotInstalled,
+		})
+	}
+	return plugins, nil
+}
+
+// GetCachedCLIRecommendedVersion returns the version of the CLI recommended by
+// the cached inventory of the OCI discovery with the specified name and image.
+// An empty version is returned if the inventory does not recommend a version.
+// The registry is never contacted: an error is returned if there is no usable
+// cached inventory.
+func GetCachedCLIRecommendedVersion(name, image string) (string, error) {
+	od, err := newCachedOCIDiscovery(name, image)
+	if err != nil {
+		return "", err
+	}
+	version, err := od.getInventory().GetCLIRecommendedVersion()
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read the cached plugin inventory of discovery '%s'", name)
+	}
+	return version, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/clientconfighelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// verifyPluginSignaturesSetting is the setting of a discovery source, in the CLI
+// configuration, which requires the signature of every plugin binary image of
+// the discovery source to be verified before installation
+const verifyPluginSignaturesSetting = "verifyPluginSignatures"
+
+// SetVerifyPluginSignatures enables or disables the verification of the signature
+// of the plugin binary images of the discovery source
+func SetVerifyPluginSignatures(discoveryName string, verify bool) error {
+	if !verify {
+		return clientconfighelpers.DeleteDiscoverySourceSetting(discoveryName, verifyPluginSignaturesSetting)
+	}
+	return clientconfighelpers.SetDiscoverySourceSetting(discoveryName, verifyPluginSignaturesSetting, true)
+}
+
+// IsPluginSignatureVerificationEnabled returns true if the signature of the plugin
+// binary images of the discovery source must be verified before installation.
+// The verification is enabled for the discovery images listed in the
+// TANZU_CLI_PLUGIN_ARTIFACT_SIGNATURE_VERIFICATION_DISCOVERY_LIST variable
+// regardless of the setting of the discovery source.
+func IsPluginSignatureVerificationEnabled(discoveryName, image string) (bool, error) {
+	if _, exists := getDiscoveryImagesFromEnv(constants.PluginArtifactSignatureVerificationDiscoveryList)[strings.TrimSpace(image)]; exists {
+		return true, nil
+	}
+	var verify bool
+	if _, err := clientconfighelpers.GetDiscoverySourceSetting(discoveryName, verifyPluginSignaturesSetting, &verify); err != nil {
+		return false, errors.Wrapf(err, "unable to read the plugin signature verification setting of discovery source '%s'", discoveryName)
+	}
+	return verify, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// setupCLIConfigTest uses a temporary CLI configuration with the specified OCI discovery sources
+func setupCLIConfigTest(t *testing.T, sourceNames ...string) func() {
+	dir, err := os.MkdirTemp("", "cli_config")
+	assert.Nil(t, err)
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+
+	for _, name := range sourceNames {
+		assert.Nil(t, configlib.SetCLIDiscoverySource(configtypes.PluginDiscovery{
+			OCI: &configtypes.OCIDiscovery{Name: name, Image: name + ".example.com/plugins/plugin-inventory:latest"},
+		}))
+	}
+	return func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestPluginSignatureVerification(t *testing.T) {
+	assert := assert.New(t)
+	defer setupCLIConfigTest(t, "default", "other")()
+	t.Setenv(constants.PluginArtifactSignatureVerificationDiscoveryList, "")
+
+	// The setting can only be enabled for a configured discovery source
+	assert.NotNil(SetVerifyPluginSignatures("unknown", true))
+
+	enabled, err := IsPluginSignatureVerificationEnabled("default", "default.example.com/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.False(enabled)
+
+	assert.Nil(SetVerifyPluginSignatures("default", true))
+	enabled, err = IsPluginSignatureVerificationEnabled("default", "default.example.com/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.True(enabled)
+	enabled, err = IsPluginSignatureVerificationEnabled("other", "other.example.com/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.False(enabled)
+
+	// The environment variable enables the verification for the listed images
+	t.Setenv(constants.PluginArtifactSignatureVerificationDiscoveryList, "other.example.com/plugins/plugin-inventory:latest")
+	enabled, err = IsPluginSignatureVerificationEnabled("other", "other.example.com/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.True(enabled)
+
+	assert.Nil(SetVerifyPluginSignatures("default", false))
+	enabled, err = IsPluginSignatureVerificationEnabled("default", "default.example.com/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.False(enabled)
+}
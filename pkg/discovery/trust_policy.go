@@ -0,0 +1,164 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/clientconfighelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// trustPoliciesSetting is the CLI setting holding the trust policies of the discovery images
+const trustPoliciesSetting = "trustPolicies"
+
+// TrustPolicy specifies the public keys trusted to sign the discovery
+// images matching an image pattern
+type TrustPolicy struct {
+	// ImagePattern is a discovery image or a pattern where '*' matches
+	// any sequence of characters.
+	// E.g., projects.registry.vmware.com/tanzu_cli/*
+	ImagePattern string `yaml:"imagePattern" json:"imagePattern"`
+	// PublicKeys is the list of public keys trusted for the matching
+	// images. Any of the keys can verify an image which allows to
+	// rotate keys without interruption.
+	PublicKeys []string `yaml:"publicKeys" json:"publicKeys"`
+}
+
+// GetTrustPolicies returns all the configured trust policies
+func GetTrustPolicies() ([]TrustPolicy, error) {
+	var policies []TrustPolicy
+	if _, err := clientconfighelpers.GetCLISetting(trustPoliciesSetting, &policies); err != nil {
+		return nil, errors.Wrap(err, "unable to read the trust policies")
+	}
+	return policies, nil
+}
+
+func saveTrustPolicies(policies []TrustPolicy) error {
+	if len(policies) == 0 {
+		return clientconfighelpers.DeleteCLISetting(trustPoliciesSetting)
+	}
+	return clientconfighelpers.SetCLISetting(trustPoliciesSetting, policies)
+}
+
+// AddTrustPolicyPublicKeys adds the public keys to the trust policy of
+// the image pattern. The trust policy is created if it does not exist.
+func AddTrustPolicyPublicKeys(imagePattern string, publicKeys []string) error {
+	imagePattern = strings.TrimSpace(imagePattern)
+	if imagePattern == "" {
+		return errors.New("image pattern cannot be empty")
+	}
+	if len(publicKeys) == 0 {
+		return errors.New("at least one public key must be specified")
+	}
+	policies, err := GetTrustPolicies()
+	if err != nil {
+		return err
+	}
+
+	idx := findTrustPolicy(policies, imagePattern)
+	if idx == -1 {
+		policies = append(policies, TrustPolicy{ImagePattern: imagePattern})
+		idx = len(policies) - 1
+	}
+	for _, key := range publicKeys {
+		if !utils.ContainsString(policies[idx].PublicKeys, key) {
+			policies[idx].PublicKeys = append(policies[idx].PublicKeys, key)
+		}
+	}
+	return saveTrustPolicies(policies)
+}
+
+// DeleteTrustPolicyPublicKeys removes the public keys from the trust policy of
+// the image pattern. If no public key is specified or if no key remains, the
+// trust policy is removed. An error is returned if a specified key is not trusted.
+func DeleteTrustPolicyPublicKeys(imagePattern string, publicKeys []string) error {
+	policies, err := GetTrustPolicies()
+	if err != nil {
+		return err
+	}
+
+	idx := findTrustPolicy(policies, strings.TrimSpace(imagePattern))
+	if idx == -1 {
+		return errors.Errorf("trust policy for %q not found", imagePattern)
+	}
+	for _, key := range publicKeys {
+		if !utils.ContainsString(policies[idx].PublicKeys, key) {
+			return errors.Errorf("public key %q is not trusted for %q", key, imagePattern)
+		}
+	}
+
+	var remainingKeys []string
+	for _, key := range policies[idx].PublicKeys {
+		if len(publicKeys) != 0 && !utils.ContainsString(publicKeys, key) {
+			remainingKeys = append(remainingKeys, key)
+		}
+	}
+	if len(remainingKeys) == 0 {
+		policies = append(policies[:idx], policies[idx+1:]...)
+	} else {
+		policies[idx].PublicKeys = remainingKeys
+	}
+	return saveTrustPolicies(policies)
+}
+
+// GetTrustedPublicKeys returns the public keys trusted to sign the specified
+// discovery image based on the trust policies matching the image.
+// If no trust policy matches the image, the public key configured through the
+// TANZU_CLI_PLUGIN_DISCOVERY_IMAGE_SIGNATURE_PUBLIC_KEY_PATH variable is
+// returned, if any.
+func GetTrustedPublicKeys(image string) ([]string, error) {
+	policies, err := GetTrustPolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, policy := range policies {
+		if !imageMatchesPattern(image, policy.ImagePattern) {
+			continue
+		}
+		for _, key := range policy.PublicKeys {
+			if !utils.ContainsString(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		if customPublicKeyPath := os.Getenv(constants.PublicKeyPathForPluginDiscoveryImageSignature); customPublicKeyPath != "" {
+			keys = append(keys, customPublicKeyPath)
+		}
+	}
+	return keys, nil
+}
+
+func findTrustPolicy(policies []TrustPolicy, imagePattern string) int {
+	for i := range policies {
+		if policies[i].ImagePattern == imagePattern {
+			return i
+		}
+	}
+	return -1
+}
+
+// imageMatchesPattern returns true if the image matches the pattern
+// where '*' matches any sequence of characters
+func imageMatchesPattern(image, pattern string) bool {
+	image = strings.TrimSpace(image)
+	pattern = strings.TrimSpace(pattern)
+	if image == pattern {
+		return true
+	}
+	if !strings.Contains(pattern, "*") {
+		return false
+	}
+	regex := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	matched, err := regexp.MatchString(regex, image)
+	return err == nil && matched
+}
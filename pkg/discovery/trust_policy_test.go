@@ -0,0 +1,112 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestTrustPolicies(t *testing.T) {
+	assert := assert.New(t)
+	defer setupCLIConfigTest(t)()
+
+	policies, err := GetTrustPolicies()
+	assert.Nil(err)
+	assert.Empty(policies)
+
+	assert.Nil(AddTrustPolicyPublicKeys("registry.example.com/*", []string{"/keys/old.pub"}))
+	assert.Nil(AddTrustPolicyPublicKeys("registry.example.com/*", []string{"/keys/new.pub", "/keys/old.pub"}))
+	assert.Nil(AddTrustPolicyPublicKeys("other.example.com/plugins/plugin-inventory:latest", []string{"/keys/other.pub"}))
+
+	policies, err = GetTrustPolicies()
+	assert.Nil(err)
+	assert.Equal([]TrustPolicy{
+		{ImagePattern: "registry.example.com/*", PublicKeys: []string{"/keys/old.pub", "/keys/new.pub"}},
+		{ImagePattern: "other.example.com/plugins/plugin-inventory:latest", PublicKeys: []string{"/keys/other.pub"}},
+	}, policies)
+
+	// A pattern covers a family of images, whatever the name of their discovery source
+	keys, err := GetTrustedPublicKeys("registry.example.com/tanzu-cli/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.Equal([]string{"/keys/old.pub", "/keys/new.pub"}, keys)
+	keys, err = GetTrustedPublicKeys("registry.example.com/org/plugin-inventory:v2")
+	assert.Nil(err)
+	assert.Equal([]string{"/keys/old.pub", "/keys/new.pub"}, keys)
+
+	keys, err = GetTrustedPublicKeys("other.example.com/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.Equal([]string{"/keys/other.pub"}, keys)
+
+	// Complete the key rotation
+	assert.Nil(DeleteTrustPolicyPublicKeys("registry.example.com/*", []string{"/keys/old.pub"}))
+	keys, err = GetTrustedPublicKeys("registry.example.com/tanzu-cli/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.Equal([]string{"/keys/new.pub"}, keys)
+
+	// A key which is not trusted cannot be deleted
+	err = DeleteTrustPolicyPublicKeys("registry.example.com/*", []string{"/keys/old.pub"})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `public key "/keys/old.pub" is not trusted`)
+	keys, err = GetTrustedPublicKeys("registry.example.com/tanzu-cli/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.Equal([]string{"/keys/new.pub"}, keys)
+
+	// Deleting the last key deletes the policy
+	assert.Nil(DeleteTrustPolicyPublicKeys("other.example.com/plugins/plugin-inventory:latest", []string{"/keys/other.pub"}))
+	assert.Nil(DeleteTrustPolicyPublicKeys("registry.example.com/*", nil))
+	policies, err = GetTrustPolicies()
+	assert.Nil(err)
+	assert.Empty(policies)
+
+	err = DeleteTrustPolicyPublicKeys("registry.example.com/*", nil)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "not found")
+}
+
+func TestAddTrustPolicyPublicKeysInvalid(t *testing.T) {
+	assert := assert.New(t)
+	defer setupCLIConfigTest(t)()
+
+	err := AddTrustPolicyPublicKeys(" ", []string{"/keys/new.pub"})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "image pattern cannot be empty")
+
+	assert.NotNil(AddTrustPolicyPublicKeys("registry.example.com/*", nil))
+}
+
+func TestGetTrustedPublicKeysFallsBackToEnvironment(t *testing.T) {
+	assert := assert.New(t)
+	defer setupCLIConfigTest(t)()
+
+	keys, err := GetTrustedPublicKeys("registry.example.com/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.Empty(keys)
+
+	t.Setenv(constants.PublicKeyPathForPluginDiscoveryImageSignature, "/keys/env.pub")
+	keys, err = GetTrustedPublicKeys("registry.example.com/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.Equal([]string{"/keys/env.pub"}, keys)
+
+	// A matching trust policy takes precedence
+	assert.Nil(AddTrustPolicyPublicKeys("registry.example.com/plugins/*", []string{"/keys/policy.pub"}))
+	keys, err = GetTrustedPublicKeys("registry.example.com/plugins/plugin-inventory:latest")
+	assert.Nil(err)
+	assert.Equal([]string{"/keys/policy.pub"}, keys)
+}
+
+func TestImageMatchesPattern(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(imageMatchesPattern("registry.example.com/plugins/plugin-inventory:latest", "registry.example.com/plugins/plugin-inventory:latest"))
+	assert.True(imageMatchesPattern("registry.example.com/plugins/plugin-inventory:latest", "registry.example.com/*"))
+	assert.True(imageMatchesPattern("registry.example.com/plugins/plugin-inventory:latest", "*/plugin-inventory:*"))
+	assert.False(imageMatchesPattern("registry.example.com/plugins/plugin-inventory:latest", "registry.example.com/other/*"))
+	assert.False(imageMatchesPattern("registry.example.com/plugins/plugin-inventory:latest", "registry.example.com"))
+	// Regex characters are not interpreted
+	assert.False(imageMatchesPattern("registryXexample.com/plugins", "registry.example.com/*"))
+}
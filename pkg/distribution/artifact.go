@@ -8,6 +8,7 @@ import (
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/artifact"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper"
 )
 
 // Artifact points to an individual plugin binary specific to a version and
@@ -27,6 +28,10 @@ type Artifact struct {
 
 	// Arch of the plugin binary in `GOARCH` format.
 	Arch string
+
+	// SignatureVerifier, when set, is used to verify the signature of
+	// the OCI image of the plugin binary before fetching it.
+	SignatureVerifier cosignhelper.Cosignhelper `json:"-" yaml:"-"`
 }
 
 // ArtifactList contains an Artifact object for every supported platform of a
@@ -66,6 +71,9 @@ func (aMap Artifacts) Fetch(version, os, arch string) ([]byte, error) {
 	}
 
 	if a.Image != "" {
+		if a.SignatureVerifier != nil {
+			return artifact.NewOCIArtifactWithSignatureVerification(a.Image, a.SignatureVerifier).Fetch()
+		}
 		return artifact.NewOCIArtifact(a.Image).Fetch()
 	}
 	if a.URI != "" {
@@ -0,0 +1,275 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package doctor
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/clientconfighelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+)
+
+// proxyEnvVariables are the environment variables configuring the proxy
+var proxyEnvVariables = []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"}
+
+// checkConfig checks that the configuration files of the CLI can be parsed
+func checkConfig() []CheckResult {
+	var results []CheckResult
+
+	configPath, _ := configlib.ClientConfigPath()
+	if _, err := configlib.GetClientConfig(); err != nil {
+		results = append(results, failed(configPath, fmt.Sprintf("the CLI configuration cannot be read: %v", err),
+			"fix the syntax of the configuration files or move them away to start from a new configuration"))
+	} else {
+		results = append(results, passed(configPath, "the CLI configuration can be read"))
+	}
+
+	if _, err := discovery.GetTrustPolicies(); err != nil {
+		results = append(results, failed("trust policies", err.Error(),
+			"fix the trust policies of the discovery sources using 'tanzu plugin source trust'"))
+	}
+	return append(results, checkCacheTTLs()...)
+}
+
+// checkCacheTTLs checks the cache TTL of every discovery source of the CLI and the
+// default cache TTL used by the discovery sources without a TTL
+func checkCacheTTLs() []CheckResult {
+	var results []CheckResult
+	_, defaultErr := discovery.GetCacheTTL("")
+	if defaultErr != nil {
+		results = append(results, failed("cache TTLs", defaultErr.Error(),
+			fmt.Sprintf("fix the value of the %s variable", constants.PluginDiscoveryCacheTTL)))
+	}
+
+	sources, _ := configlib.GetCLIDiscoverySources()
+	for _, source := range sources {
+		if source.OCI == nil {
+			continue
+		}
+		// The discovery sources without a TTL report the error of the default TTL
+		if _, err := discovery.GetCacheTTL(source.OCI.Name); err != nil && (defaultErr == nil || err.Error() != defaultErr.Error()) {
+			results = append(results, failed(source.OCI.Name, err.Error(),
+				fmt.Sprintf("fix the cache TTL using 'tanzu plugin source update %s --cache-ttl <duration>'", source.OCI.Name)))
+		}
+	}
+	return results
+}
+
+// checkDiscoverySources checks that the discovery sources of the CLI and of
+// the current contexts are reachable and that their signature can be verified
+func checkDiscoverySources() []CheckResult {
+	if discovery.IsOfflineModeForced() {
+		return []CheckResult{warned("", "offline mode is enabled, the discovery sources were not checked",
+			fmt.Sprintf("unset the %s variable to check the discovery sources", constants.PluginDiscoveryOffline))}
+	}
+
+	sources, err := getAllDiscoverySources()
+	if err != nil {
+		return []CheckResult{failed("", fmt.Sprintf("unable to get the discovery sources: %v", err),
+			"check that the CLI configuration can be read")}
+	}
+	if len(sources) == 0 {
+		return []CheckResult{warned("", "no discovery source is configured",
+			"add a discovery source using 'tanzu plugin source add'")}
+	}
+
+	var results []CheckResult
+	for _, source := range sources {
+		d, err := discovery.CreateDiscoveryFromV1alpha1(source, nil)
+		if err != nil {
+			results = append(results, failed("", err.Error(), "fix or delete the discovery source using 'tanzu plugin source'"))
+			continue
+		}
+		results = append(results, checkDiscoverySource(d))
+	}
+	return results
+}
+
+func checkDiscoverySource(d discovery.Discovery) CheckResult {
+	verifiable, ok := d.(discovery.VerifiableDiscovery)
+	if !ok {
+		if _, err := d.List(); err != nil {
+			return failed(d.Name(), fmt.Sprintf("unable to list the plugins of the discovery source: %v", err),
+				"check the URI of the discovery source using 'tanzu plugin source list'")
+		}
+		return passed(d.Name(), "the discovery source is reachable")
+	}
+
+	err := verifiable.VerifySource()
+	switch {
+	case err == nil:
+		return passed(d.Name(), "the discovery source is reachable and its signature is verified")
+	case errors.Is(err, discovery.ErrSignatureVerificationSkipped):
+		return warned(d.Name(), "the discovery source is reachable but its signature verification is skipped",
+			fmt.Sprintf("remove the discovery image from the %s variable once its public key is trusted", constants.PluginDiscoveryImageSignatureVerificationSkipList))
+	default:
+		return failed(d.Name(), err.Error(),
+			"check the network and proxy settings and the URI of the discovery source; if the image is signed with another key, trust it using 'tanzu plugin source trust add'")
+	}
+}
+
+// getAllDiscoverySources returns the stand-alone discovery sources
+// and the discovery sources of the current contexts
+func getAllDiscoverySources() ([]configtypes.PluginDiscovery, error) {
+	sources, err := pluginmanager.GetDiscoverySourcesForContext("")
+	if err != nil {
+		return nil, err
+	}
+	currentContexts, err := configlib.GetAllCurrentContextsMap()
+	if err != nil {
+		return sources, nil
+	}
+	for _, ctx := range currentContexts {
+		contextSources, err := pluginmanager.GetDiscoverySourcesForContext(ctx.Name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, contextSources...)
+	}
+	return sources, nil
+}
+
+// checkInstalledPlugins checks that the catalog is consistent with the plugin binaries on disk
+func checkInstalledPlugins() []CheckResult {
+	verificationResults, err := pluginmanager.VerifyInstalledPlugins()
+	if err != nil {
+		return []CheckResult{failed("", fmt.Sprintf("unable to verify the installed plugins: %v", err),
+			"run 'tanzu plugin repair' to rebuild the plugin catalog")}
+	}
+
+	var results []CheckResult
+	for i := range verificationResults {
+		r := &verificationResults[i]
+		switch r.Status {
+		case pluginmanager.PluginVerificationDigestMismatch, pluginmanager.PluginVerificationMissing:
+			results = append(results, failed(r.Name, fmt.Sprintf("the plugin binary %s is %s", r.Path, r.Status),
+				"run 'tanzu plugin verify --repair'"))
+		case pluginmanager.PluginVerificationOrphaned:
+			results = append(results, warned(r.Name, fmt.Sprintf("the binary %s is not used by any installed plugin", r.Path),
+				"delete the file to reclaim disk space"))
+		}
+	}
+	if len(results) == 0 {
+		results = append(results, passed("", fmt.Sprintf("%d plugin binaries verified", len(verificationResults))))
+	}
+	return results
+}
+
+// checkContexts checks that the kubeconfig of every context can be used
+// and that the current contexts have not expired
+func checkContexts() []CheckResult {
+	cfg, err := configlib.GetClientConfig()
+	if err != nil {
+		return []CheckResult{failed("", fmt.Sprintf("unable to read the contexts: %v", err),
+			"check that the CLI configuration can be read")}
+	}
+	currentContexts, err := configlib.GetAllCurrentContextsMap()
+	if err != nil {
+		return []CheckResult{failed("", fmt.Sprintf("unable to read the current contexts: %v", err),
+			"set the current context using 'tanzu context use'")}
+	}
+	isCurrent := make(map[string]bool)
+	for _, ctx := range currentContexts {
+		isCurrent[ctx.Name] = true
+	}
+
+	var results []CheckResult
+	for _, ctx := range cfg.KnownContexts {
+		if result, ok := checkContext(ctx, isCurrent[ctx.Name]); ok {
+			results = append(results, result)
+		}
+	}
+	if len(results) == 0 {
+		results = append(results, passed("", "no context is configured"))
+	}
+	return results
+}
+
+// checkContext returns the result of the check of the context, or false if
+// the context was not checked because it is not in use
+func checkContext(ctx *configtypes.Context, current bool) (CheckResult, bool) {
+	// Problems of contexts which are not in use do not prevent the CLI from working
+	problem := warned
+	if current {
+		problem = failed
+	}
+
+	if ctx.ClusterOpts != nil && ctx.ClusterOpts.Path != "" {
+		kubeconfig, err := clientcmd.LoadFromFile(ctx.ClusterOpts.Path)
+		if err != nil {
+			return problem(ctx.Name, fmt.Sprintf("unable to read the kubeconfig %s: %v", ctx.ClusterOpts.Path, err),
+				"recreate the context using 'tanzu context create' or delete it using 'tanzu context delete'"), true
+		}
+		if _, exists := kubeconfig.Contexts[ctx.ClusterOpts.Context]; !exists {
+			return problem(ctx.Name, fmt.Sprintf("the kubeconfig context %q does not exist in %s", ctx.ClusterOpts.Context, ctx.ClusterOpts.Path),
+				"recreate the context using 'tanzu context create' or delete it using 'tanzu context delete'"), true
+		}
+	}
+
+	if !current {
+		return CheckResult{}, false
+	}
+
+	if ctx.GlobalOpts != nil && !ctx.GlobalOpts.Auth.Expiration.IsZero() && ctx.GlobalOpts.Auth.Expiration.Before(time.Now()) {
+		if ctx.GlobalOpts.Auth.RefreshToken != "" {
+			return warned(ctx.Name, "the access token has expired and will be refreshed on the next use of the context", ""), true
+		}
+		return failed(ctx.Name, fmt.Sprintf("the access token expired on %s", ctx.GlobalOpts.Auth.Expiration.Format(time.RFC3339)),
+			"log in again using 'tanzu context create'"), true
+	}
+	return passed(ctx.Name, "the current context is valid"), true
+}
+
+// checkCertificatesAndProxy checks that the custom CA certificate
+// and the proxy settings can be used
+func checkCertificatesAndProxy() []CheckResult {
+	var results []CheckResult
+
+	caCertVariables := fmt.Sprintf("%s, %s or %s", constants.ProxyCACert, constants.TKGProxyCACert, constants.ConfigVariableCustomImageRepositoryCaCertificate)
+	caCert, err := clientconfighelpers.GetCustomRepositoryCaCertificateForClient()
+	switch {
+	case err != nil:
+		results = append(results, failed("CA certificate", err.Error(),
+			fmt.Sprintf("set %s to the base64 encoded PEM certificate", caCertVariables)))
+	case len(caCert) == 0:
+		results = append(results, passed("CA certificate", "no custom CA certificate is configured"))
+	case !x509.NewCertPool().AppendCertsFromPEM(caCert):
+		results = append(results, failed("CA certificate", "the custom CA certificate is not a valid PEM certificate",
+			fmt.Sprintf("set %s to the base64 encoded PEM certificate", caCertVariables)))
+	default:
+		results = append(results, passed("CA certificate", "the custom CA certificate is valid"))
+	}
+
+	if skip, _ := strconv.ParseBool(os.Getenv(constants.ConfigVariableCustomImageRepositorySkipTLSVerify)); skip {
+		results = append(results, warned("TLS verification", "the TLS verification of the image registry is disabled",
+			fmt.Sprintf("configure the registry CA certificate and unset %s", constants.ConfigVariableCustomImageRepositorySkipTLSVerify)))
+	}
+
+	for _, variable := range proxyEnvVariables {
+		value := os.Getenv(variable)
+		if value == "" {
+			continue
+		}
+		if u, err := url.Parse(value); err != nil || u.Host == "" {
+			results = append(results, failed(variable, fmt.Sprintf("the proxy URL %q is invalid", value),
+				fmt.Sprintf("set %s to a URL of the form http://proxy.example.com:3128", variable)))
+		} else {
+			results = append(results, passed(variable, fmt.Sprintf("the proxy URL %q is valid", value)))
+		}
+	}
+	return results
+}
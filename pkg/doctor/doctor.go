@@ -0,0 +1,105 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package doctor implements the diagnostic checks of the CLI installation
+package doctor
+
+import (
+	"fmt"
+)
+
+// Status is the outcome of a diagnostic check
+type Status string
+
+const (
+	// StatusPass means no problem was found
+	StatusPass Status = "pass"
+	// StatusWarn means a problem was found which does not prevent the CLI from working
+	StatusWarn Status = "warn"
+	// StatusFail means a problem was found which prevents the CLI from working
+	StatusFail Status = "fail"
+)
+
+// CheckResult is the result of a diagnostic check on one item
+type CheckResult struct {
+	// Check is the name of the check which produced the result
+	Check string `json:"check" yaml:"check"`
+	// Item is the checked item, e.g., a discovery source or a context
+	Item string `json:"item,omitempty" yaml:"item,omitempty"`
+	// Status is the outcome of the check
+	Status Status `json:"status" yaml:"status"`
+	// Message describes the outcome of the check
+	Message string `json:"message" yaml:"message"`
+	// Remediation describes how to fix the problem, if any
+	Remediation string `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+}
+
+// Check is a diagnostic check of the CLI installation
+type Check struct {
+	// Name of the check
+	Name string
+	// Run performs the check and returns one result per checked item
+	Run func() []CheckResult
+}
+
+// DefaultChecks returns the checks run by the doctor command
+func DefaultChecks() []Check {
+	return []Check{
+		{Name: "config", Run: checkConfig},
+		{Name: "discovery-sources", Run: checkDiscoverySources},
+		{Name: "plugins", Run: checkInstalledPlugins},
+		{Name: "contexts", Run: checkContexts},
+		{Name: "certificates-and-proxy", Run: checkCertificatesAndProxy},
+	}
+}
+
+// RunChecks runs the checks and returns the results of all of them.
+// The check name is set on every result.
+func RunChecks(checks []Check) []CheckResult {
+	var results []CheckResult
+	for _, check := range checks {
+		checkResults := runCheck(check)
+		for i := range checkResults {
+			checkResults[i].Check = check.Name
+		}
+		results = append(results, checkResults...)
+	}
+	return results
+}
+
+// runCheck runs the check making sure that a panicking check is
+// reported as failed instead of aborting the other checks
+func runCheck(check Check) (results []CheckResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			results = []CheckResult{failed("", fmt.Sprintf("the check could not complete: %v", r), "report this problem to the CLI maintainers")}
+		}
+	}()
+	return check.Run()
+}
+
+// Summary returns the worst status of the results
+func Summary(results []CheckResult) Status {
+	status := StatusPass
+	for i := range results {
+		switch results[i].Status {
+		case StatusFail:
+			return StatusFail
+		case StatusWarn:
+			status = StatusWarn
+		}
+	}
+	return status
+}
+
+func passed(item, message string) CheckResult {
+	return CheckResult{Item: item, Status: StatusPass, Message: message}
+}
+
+func warned(item, message, remediation string) CheckResult {
+	return CheckResult{Item: item, Status: StatusWarn, Message: message, Remediation: remediation}
+}
+
+func failed(item, message, remediation string) CheckResult {
+	return CheckResult{Item: item, Status: StatusFail, Message: message, Remediation: remediation}
+}
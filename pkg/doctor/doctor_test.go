@@ -0,0 +1,191 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package doctor
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/clientconfighelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: test-user
+  name: test-context
+current-context: test-context
+users:
+- name: test-user
+  user:
+    token: token
+`
+
+func TestRunChecksAndSummary(t *testing.T) {
+	assertions := assert.New(t)
+
+	checks := []Check{
+		{Name: "passing", Run: func() []CheckResult { return []CheckResult{passed("a", "ok")} }},
+		{Name: "warning", Run: func() []CheckResult { return []CheckResult{warned("b", "not great", "fix it")} }},
+		{Name: "panicking", Run: func() []CheckResult { panic("boom") }},
+		{Name: "empty", Run: func() []CheckResult { return nil }},
+	}
+	results := RunChecks(checks)
+	assertions.Equal(3, len(results))
+	assertions.Equal("passing", results[0].Check)
+	assertions.Equal("warning", results[1].Check)
+	assertions.Equal("panicking", results[2].Check)
+	assertions.Equal(StatusFail, results[2].Status)
+	assertions.Contains(results[2].Message, "boom")
+
+	assertions.Equal(StatusFail, Summary(results))
+	assertions.Equal(StatusWarn, Summary(results[:2]))
+	assertions.Equal(StatusPass, Summary(results[:1]))
+	assertions.Equal(StatusPass, Summary(nil))
+}
+
+func TestCheckContext(t *testing.T) {
+	assertions := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "doctor")
+	assertions.Nil(err)
+	defer os.RemoveAll(dir)
+	kubeconfigPath := filepath.Join(dir, "config")
+	assertions.Nil(os.WriteFile(kubeconfigPath, []byte(testKubeconfig), 0o600))
+
+	k8sContext := func(path, context string) *configtypes.Context {
+		return &configtypes.Context{
+			Name:        "k8s",
+			Target:      configtypes.TargetK8s,
+			ClusterOpts: &configtypes.ClusterServer{Path: path, Context: context},
+		}
+	}
+
+	// Valid kubeconfig
+	result, checked := checkContext(k8sContext(kubeconfigPath, "test-context"), true)
+	assertions.True(checked)
+	assertions.Equal(StatusPass, result.Status)
+
+	// Contexts which are not in use are only reported when there is a problem
+	_, checked = checkContext(k8sContext(kubeconfigPath, "test-context"), false)
+	assertions.False(checked)
+
+	// Missing kubeconfig context
+	result, checked = checkContext(k8sContext(kubeconfigPath, "missing"), true)
+	assertions.True(checked)
+	assertions.Equal(StatusFail, result.Status)
+	result, checked = checkContext(k8sContext(kubeconfigPath, "missing"), false)
+	assertions.True(checked)
+	assertions.Equal(StatusWarn, result.Status)
+
+	// Missing kubeconfig file
+	result, _ = checkContext(k8sContext(filepath.Join(dir, "missing"), "test-context"), true)
+	assertions.Equal(StatusFail, result.Status)
+	assertions.NotEmpty(result.Remediation)
+
+	// Expired token
+	tmcContext := &configtypes.Context{
+		Name:   "tmc",
+		Target: configtypes.TargetTMC,
+		GlobalOpts: &configtypes.GlobalServer{
+			Auth: configtypes.GlobalServerAuth{Expiration: time.Now().Add(-time.Hour)},
+		},
+	}
+	result, _ = checkContext(tmcContext, true)
+	assertions.Equal(StatusFail, result.Status)
+
+	tmcContext.GlobalOpts.Auth.RefreshToken = "refresh"
+	result, _ = checkContext(tmcContext, true)
+	assertions.Equal(StatusWarn, result.Status)
+
+	tmcContext.GlobalOpts.Auth.Expiration = time.Now().Add(time.Hour)
+	result, _ = checkContext(tmcContext, true)
+	assertions.Equal(StatusPass, result.Status)
+}
+
+func TestCheckCacheTTLs(t *testing.T) {
+	assertions := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "doctor")
+	assertions.Nil(err)
+	defer os.RemoveAll(dir)
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+	t.Setenv(constants.PluginDiscoveryCacheTTL, "")
+
+	for _, name := range []string{"default", "other"} {
+		assertions.Nil(configlib.SetCLIDiscoverySource(configtypes.PluginDiscovery{
+			OCI: &configtypes.OCIDiscovery{Name: name, Image: name + ".example.com/plugins/plugin-inventory:latest"},
+		}))
+	}
+	assertions.Nil(discovery.SetCacheTTL("default", time.Hour))
+	assertions.Empty(checkCacheTTLs())
+
+	// An invalid TTL of a discovery source is reported
+	assertions.Nil(clientconfighelpers.SetDiscoverySourceSetting("other", "cacheTTL", "invalid"))
+	results := checkCacheTTLs()
+	assertions.Equal(1, len(results))
+	assertions.Equal("other", results[0].Item)
+	assertions.Equal(StatusFail, results[0].Status)
+	assertions.Contains(results[0].Message, `invalid cache TTL "invalid" for discovery source 'other'`)
+
+	// An invalid default TTL is reported once
+	assertions.Nil(discovery.DeleteCacheTTL("other"))
+	t.Setenv(constants.PluginDiscoveryCacheTTL, "invalid")
+	results = checkCacheTTLs()
+	assertions.Equal(1, len(results))
+	assertions.Equal("cache TTLs", results[0].Item)
+	assertions.Contains(results[0].Message, constants.PluginDiscoveryCacheTTL)
+}
+
+func TestCheckCertificatesAndProxy(t *testing.T) {
+	assertions := assert.New(t)
+
+	for _, variable := range append([]string{constants.ProxyCACert, constants.TKGProxyCACert,
+		constants.ConfigVariableCustomImageRepositoryCaCertificate, constants.ConfigVariableCustomImageRepositorySkipTLSVerify}, proxyEnvVariables...) {
+		t.Setenv(variable, "")
+	}
+
+	results := checkCertificatesAndProxy()
+	assertions.Equal(1, len(results))
+	assertions.Equal(StatusPass, results[0].Status)
+
+	// Invalid base64 encoding
+	t.Setenv(constants.ProxyCACert, "not base64!")
+	results = checkCertificatesAndProxy()
+	assertions.Equal(StatusFail, results[0].Status)
+
+	// Valid base64 encoding but not a PEM certificate
+	t.Setenv(constants.ProxyCACert, base64.StdEncoding.EncodeToString([]byte("not a certificate")))
+	results = checkCertificatesAndProxy()
+	assertions.Equal(StatusFail, results[0].Status)
+	t.Setenv(constants.ProxyCACert, "")
+
+	t.Setenv(constants.ConfigVariableCustomImageRepositorySkipTLSVerify, "true")
+	t.Setenv("HTTPS_PROXY", "http://proxy.example.com:3128")
+	t.Setenv("HTTP_PROXY", "::invalid")
+	results = checkCertificatesAndProxy()
+	assertions.Equal(4, len(results))
+	assertions.Equal(StatusWarn, results[1].Status)
+	assertions.Equal("HTTPS_PROXY", results[2].Item)
+	assertions.Equal(StatusPass, results[2].Status)
+	assertions.Equal("HTTP_PROXY", results[3].Item)
+	assertions.Equal(StatusFail, results[3].Status)
+}
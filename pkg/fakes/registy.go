@@ -87,6 +87,18 @@ type Registry struct {
 		result1 []string
 		result2 error
 	}
+	UploadImageStub        func(string, string) error
+	uploadImageMutex       sync.RWMutex
+	uploadImageArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	uploadImageReturns struct {
+		result1 error
+	}
+	uploadImageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -475,6 +487,68 @@ func (fake *Registry) ListImageTagsReturnsOnCall(i int, result1 []string, result
 	}{result1, result2}
 }
 
+func (fake *Registry) UploadImage(arg1 string, arg2 string) error {
+	fake.uploadImageMutex.Lock()
+	ret, specificReturn := fake.uploadImageReturnsOnCall[len(fake.uploadImageArgsForCall)]
+	fake.uploadImageArgsForCall = append(fake.uploadImageArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UploadImageStub
+	fakeReturns := fake.uploadImageReturns
+	fake.recordInvocation("UploadImage", []interface{}{arg1, arg2})
+	fake.uploadImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Registry) UploadImageCallCount() int {
+	fake.uploadImageMutex.RLock()
+	defer fake.uploadImageMutex.RUnlock()
+	return len(fake.uploadImageArgsForCall)
+}
+
+func (fake *Registry) UploadImageCalls(stub func(string, string) error) {
+	fake.uploadImageMutex.Lock()
+	defer fake.uploadImageMutex.Unlock()
+	fake.UploadImageStub = stub
+}
+
+func (fake *Registry) UploadImageArgsForCall(i int) (string, string) {
+	fake.uploadImageMutex.RLock()
+	defer fake.uploadImageMutex.RUnlock()
+	argsForCall := fake.uploadImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Registry) UploadImageReturns(result1 error) {
+	fake.uploadImageMutex.Lock()
+	defer fake.uploadImageMutex.Unlock()
+	fake.UploadImageStub = nil
+	fake.uploadImageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Registry) UploadImageReturnsOnCall(i int, result1 error) {
+	fake.uploadImageMutex.Lock()
+	defer fake.uploadImageMutex.Unlock()
+	fake.UploadImageStub = nil
+	if fake.uploadImageReturnsOnCall == nil {
+		fake.uploadImageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uploadImageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Registry) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -490,6 +564,8 @@ func (fake *Registry) Invocations() map[string][][]interface{} {
 	defer fake.getImageDigestMutex.RUnlock()
 	fake.listImageTagsMutex.RLock()
 	defer fake.listImageTagsMutex.RUnlock()
+	fake.uploadImageMutex.RLock()
+	defer fake.uploadImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
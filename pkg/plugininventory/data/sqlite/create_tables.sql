@@ -25,3 +25,7 @@ CREATE TABLE IF NOT EXISTS "PluginGroups" (
 		"Hidden"             TEXT NOT NULL,
 		PRIMARY KEY("Vendor", "Publisher", "GroupName", "PluginName", "Target", "Version")
 );
+
+CREATE TABLE IF NOT EXISTS "CLIVersions" (
+		"RecommendedVersion" TEXT NOT NULL
+);
@@ -42,6 +42,13 @@ type PluginInventory interface {
 
 	// UpdatePluginGroupActivationState updates plugin-group metadata to activate or deactivate the plugin-group
 	UpdatePluginGroupActivationState(*PluginGroup) error
+
+	// GetCLIRecommendedVersion returns the version of the Tanzu CLI recommended
+	// by the inventory, or an empty string if the inventory does not recommend one.
+	GetCLIRecommendedVersion() (string, error)
+
+	// SetCLIRecommendedVersion sets the version of the Tanzu CLI recommended by the inventory
+	SetCLIRecommendedVersion(version string) error
 }
 
 // PluginInventoryEntry represents the inventory information
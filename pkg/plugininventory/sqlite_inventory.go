@@ -47,6 +47,9 @@ const (
 
 	// groupSelectQuery is the query used to extract plugin groups from the PluginGroups table
 	groupSelectQuery = "SELECT Vendor,Publisher,GroupName,PluginName,Target,Version,Mandatory,Hidden FROM PluginGroups ORDER by Vendor,Publisher,GroupName,PluginName"
+
+	// cliVersionSelectQuery is the query used to extract the recommended CLI version from the CLIVersions table
+	cliVersionSelectQuery = "SELECT RecommendedVersion FROM CLIVersions"
 )
 
 // Structure of each row of the PluginBinaries table within the SQLite database
@@ -544,3 +547,46 @@ func (b *SQLiteInventory) UpdatePluginGroupActivationState(pg *PluginGroup) erro
 
 	return nil
 }
+
+// GetCLIRecommendedVersion returns the version of the Tanzu CLI recommended
+// by the inventory, or an empty string if the inventory does not recommend one.
+// The inventories created before the CLIVersions table was introduced do not
+// recommend a version.
+func (b *SQLiteInventory) GetCLIRecommendedVersion() (string, error) {
+	db, err := sql.Open("sqlite", b.inventoryFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to open the DB at '%s' for the CLI version", b.inventoryFile)
+	}
+	defer db.Close()
+
+	var version string
+	err = db.QueryRow(cliVersionSelectQuery).Scan(&version)
+	if err == sql.ErrNoRows || (err != nil && strings.Contains(err.Error(), "no such table")) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read the CLI version from the DB at '%s'", b.inventoryFile)
+	}
+	return version, nil
+}
+
+// SetCLIRecommendedVersion sets the version of the Tanzu CLI recommended by the inventory
+func (b *SQLiteInventory) SetCLIRecommendedVersion(version string) error {
+	db, err := sql.Open("sqlite", b.inventoryFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the DB from '%s' file", b.inventoryFile)
+	}
+	defer db.Close()
+
+	// The table is missing from the inventories created by an earlier version of the schema
+	if _, err = db.Exec(CreateTablesSchema); err != nil {
+		return errors.Wrap(err, "error while creating tables to the database")
+	}
+	if _, err = db.Exec("DELETE FROM CLIVersions;"); err != nil {
+		return errors.Wrap(err, "unable to update the CLI version")
+	}
+	if _, err = db.Exec("INSERT INTO CLIVersions VALUES(?);", version); err != nil {
+		return errors.Wrap(err, "unable to update the CLI version")
+	}
+	return nil
+}
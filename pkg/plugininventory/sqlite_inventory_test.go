@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 
 	// Import the sqlite driver
@@ -1108,6 +1109,63 @@ var _ = Describe("Unit tests for plugin inventory", func() {
 			})
 		})
 	})
+	Describe("Recommended CLI version", func() {
+		BeforeEach(func() {
+			tmpDir, err = os.MkdirTemp(os.TempDir(), "")
+			Expect(err).To(BeNil(), "unable to create temporary directory")
+
+			// Create DB file
+			dbFile, err = os.Create(filepath.Join(tmpDir, SQliteDBFileName))
+			Expect(err).To(BeNil())
+
+			inventory = NewSQLiteInventory(dbFile.Name(), tmpDir)
+		})
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		Context("When the database does not recommend a CLI version", func() {
+			It("should return an empty version", func() {
+				err = inventory.CreateSchema()
+				Expect(err).To(BeNil())
+				version, err := inventory.GetCLIRecommendedVersion()
+				Expect(err).To(BeNil())
+				Expect(version).To(BeEmpty())
+			})
+			It("should return an empty version when the database was created without the CLIVersions table", func() {
+				db, err := sql.Open("sqlite", dbFile.Name())
+				Expect(err).To(BeNil())
+				_, err = db.Exec(strings.Split(CreateTablesSchema, ";")[0])
+				Expect(err).To(BeNil())
+				db.Close()
+
+				version, err := inventory.GetCLIRecommendedVersion()
+				Expect(err).To(BeNil())
+				Expect(version).To(BeEmpty())
+
+				// The table is created when setting the version
+				err = inventory.SetCLIRecommendedVersion("v1.1.0")
+				Expect(err).To(BeNil())
+				version, err = inventory.GetCLIRecommendedVersion()
+				Expect(err).To(BeNil())
+				Expect(version).To(Equal("v1.1.0"))
+			})
+		})
+		Context("When the recommended CLI version is set", func() {
+			It("should return the last version set", func() {
+				err = inventory.CreateSchema()
+				Expect(err).To(BeNil())
+				err = inventory.SetCLIRecommendedVersion("v1.0.0")
+				Expect(err).To(BeNil())
+				err = inventory.SetCLIRecommendedVersion("v1.1.0")
+				Expect(err).To(BeNil())
+
+				version, err := inventory.GetCLIRecommendedVersion()
+				Expect(err).To(BeNil())
+				Expect(version).To(Equal("v1.1.0"))
+			})
+		})
+	})
 })
 
 type pluginGroupSorter []*PluginGroup
@@ -0,0 +1,188 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+// pluginHistoryFileName is the name of the journal of the plugin operations
+const pluginHistoryFileName = "plugin_history.jsonl"
+
+// Operations recorded in the plugin history
+const (
+	PluginHistoryOperationInstall  = "install"
+	PluginHistoryOperationUpgrade  = "upgrade"
+	PluginHistoryOperationSync     = "sync"
+	PluginHistoryOperationDelete   = "delete"
+	PluginHistoryOperationUse      = "use"
+	PluginHistoryOperationRollback = "rollback"
+)
+
+// PluginHistoryEntry is an entry of the journal of the plugin operations
+type PluginHistoryEntry struct {
+	// Timestamp is the time of the operation
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	// Operation is the operation performed on the plugin
+	Operation string `json:"operation" yaml:"operation"`
+	// Name is the name of the plugin
+	Name string `json:"name" yaml:"name"`
+	// Target is the target of the plugin
+	Target configtypes.Target `json:"target" yaml:"target"`
+	// ContextName is the context the plugin is installed for, empty for a stand-alone plugin
+	ContextName string `json:"context,omitempty" yaml:"context,omitempty"`
+	// OldVersion is the version of the plugin before the operation, if any
+	OldVersion string `json:"oldVersion,omitempty" yaml:"oldVersion,omitempty"`
+	// NewVersion is the version of the plugin after the operation, if any
+	NewVersion string `json:"newVersion,omitempty" yaml:"newVersion,omitempty"`
+	// Digest is the digest of the plugin binary after the operation
+	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
+	// Source is the discovery source the plugin was installed from
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// getPluginHistoryPath returns the path of the journal of the plugin operations,
+// which is kept beside the plugin catalog
+func getPluginHistoryPath() string {
+	return filepath.Join(catalog.GetCatalogCacheDir(), pluginHistoryFileName)
+}
+
+// recordPluginHistory appends an entry to the journal of the plugin operations.
+// The previous and current plugins are nil if the plugin was not installed
+// before or after the operation. Failing to record the entry does not fail
+// the operation.
+func recordPluginHistory(operation, contextName string, previous, current *cli.PluginInfo) {
+	entry := PluginHistoryEntry{
+		Timestamp:   time.Now().UTC(),
+		Operation:   operation,
+		ContextName: contextName,
+	}
+	if previous != nil {
+		entry.Name = previous.Name
+		entry.Target = previous.Target
+		entry.OldVersion = previous.Version
+	}
+	if current != nil {
+		entry.Name = current.Name
+		entry.Target = current.Target
+		entry.NewVersion = current.Version
+		entry.Digest = current.Digest
+		entry.Source = current.Discovery
+	}
+	if err := appendPluginHistoryEntry(&entry); err != nil {
+		log.V(6).Infof("unable to record the %s of plugin %q in the history: %v", operation, entry.Name, err)
+	}
+}
+
+func appendPluginHistoryEntry(entry *PluginHistoryEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	historyPath := getPluginHistoryPath()
+	if err := os.MkdirAll(filepath.Dir(historyPath), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// A single write of a line keeps the journal consistent
+	// if multiple processes append to it at the same time
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// GetPluginHistory returns the entries of the journal of the plugin operations,
+// from the oldest to the most recent one. Only the entries of the plugin are
+// returned if a plugin name is specified, and only the entries of the target if
+// a target is specified.
+func GetPluginHistory(pluginName string, target configtypes.Target) ([]PluginHistoryEntry, error) {
+	f, err := os.Open(getPluginHistoryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "unable to read the plugin history")
+	}
+	defer f.Close()
+
+	var entries []PluginHistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry PluginHistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// Skip the entries which cannot be decoded, e.g., a partially written line
+			log.V(6).Infof("skipping invalid plugin history entry: %v", err)
+			continue
+		}
+		if pluginName != "" && entry.Name != pluginName {
+			continue
+		}
+		if target != configtypes.TargetUnknown && entry.Target != target {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to read the plugin history")
+	}
+	return entries, nil
+}
+
+// RollbackPlugin restores the version of a stand-alone plugin which was active
+// before the last operation recorded for the plugin in the history. The previous
+// version must still be installed side by side with the current version.
+// Rolling back twice restores the version active before the first rollback.
+func RollbackPlugin(pluginName string, target configtypes.Target) (*PluginHistoryEntry, error) {
+	entries, err := GetPluginHistory(pluginName, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *PluginHistoryEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].ContextName == "" {
+			last = &entries[i]
+			break
+		}
+	}
+	if last == nil {
+		return nil, errors.Errorf("no operation on the stand-alone plugin '%v' is recorded in the history", pluginName)
+	}
+	if last.OldVersion == "" {
+		return nil, errors.Errorf("no previous version of plugin '%v' to roll back to, the last %s operation installed version %s", pluginName, last.Operation, last.NewVersion)
+	}
+
+	unlock, err := catalog.LockPluginRoot()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	plugin, err := findInstalledPluginVersion(pluginName, last.OldVersion, last.Target)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to roll back plugin '%v' to version %s", pluginName, last.OldVersion)
+	}
+	previous, err := activateStandalonePlugin(plugin)
+	if err != nil {
+		return nil, err
+	}
+	recordPluginHistory(PluginHistoryOperationRollback, "", previous, plugin)
+	return last, nil
+}
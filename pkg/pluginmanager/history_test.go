@@ -0,0 +1,123 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func activeStandaloneVersion(t *testing.T, name string, target configtypes.Target) string {
+	c, err := catalog.NewContextCatalog("")
+	assert.Nil(t, err)
+	pd, exists := c.Get(catalog.PluginNameTarget(name, target))
+	if !exists {
+		return ""
+	}
+	return pd.Version
+}
+
+func TestPluginHistoryAndRollback(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupLocalDistroForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// Turn off central repo feature
+	featureArray := strings.Split(constants.FeatureDisableCentralRepositoryForTesting, ".")
+	err := configlib.SetFeature(featureArray[1], featureArray[2], "true")
+	assertions.Nil(err)
+
+	// Nothing to roll back before any operation
+	_, err = RollbackPlugin("management-cluster", configtypes.TargetK8s)
+	assertions.NotNil(err)
+
+	assertions.Nil(InstallStandalonePlugin("management-cluster", "v1.6.0", configtypes.TargetK8s))
+	entries, err := GetPluginHistory("management-cluster", configtypes.TargetUnknown)
+	assertions.Nil(err)
+	assertions.Equal(1, len(entries))
+	assertions.Equal(PluginHistoryOperationInstall, entries[0].Operation)
+	assertions.Equal("", entries[0].OldVersion)
+	assertions.Equal("v1.6.0", entries[0].NewVersion)
+	assertions.NotEmpty(entries[0].Digest)
+
+	// The first installation cannot be rolled back
+	_, err = RollbackPlugin("management-cluster", configtypes.TargetK8s)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "no previous version")
+
+	// Switch to another version installed side by side
+	fakeInstallPluginVersion(t, "management-cluster", "v1.7.0", configtypes.TargetK8s)
+	assertions.Nil(UsePluginVersion("management-cluster", "v1.6.0", configtypes.TargetK8s))
+	assertions.Nil(UsePluginVersion("management-cluster", "v1.7.0", configtypes.TargetK8s))
+	entries, err = GetPluginHistory("management-cluster", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.Equal(3, len(entries))
+	assertions.Equal(PluginHistoryOperationUse, entries[2].Operation)
+	assertions.Equal("v1.6.0", entries[2].OldVersion)
+	assertions.Equal("v1.7.0", entries[2].NewVersion)
+
+	// Roll back to the previous version, and back again
+	entry, err := RollbackPlugin("management-cluster", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.Equal("v1.6.0", entry.OldVersion)
+	assertions.Equal("v1.6.0", activeStandaloneVersion(t, "management-cluster", configtypes.TargetK8s))
+	_, err = RollbackPlugin("management-cluster", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.Equal("v1.7.0", activeStandaloneVersion(t, "management-cluster", configtypes.TargetK8s))
+
+	// A deleted plugin can be restored
+	assertions.Nil(DeletePlugin(DeletePluginOptions{PluginName: "management-cluster", Target: configtypes.TargetK8s, ForceDelete: true}))
+	assertions.Equal("", activeStandaloneVersion(t, "management-cluster", configtypes.TargetK8s))
+	_, err = RollbackPlugin("management-cluster", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.Equal("v1.7.0", activeStandaloneVersion(t, "management-cluster", configtypes.TargetK8s))
+
+	entries, err = GetPluginHistory("", configtypes.TargetUnknown)
+	assertions.Nil(err)
+	operations := make([]string, len(entries))
+	for i := range entries {
+		operations[i] = entries[i].Operation
+	}
+	assertions.Equal([]string{"install", "use", "use", "rollback", "rollback", "delete", "rollback"}, operations)
+
+	// Invalid entries of the journal are skipped
+	f, err := os.OpenFile(getPluginHistoryPath(), os.O_APPEND|os.O_WRONLY, 0o644)
+	assertions.Nil(err)
+	_, err = f.WriteString("{\"timestamp\": \n")
+	assertions.Nil(err)
+	f.Close()
+	entries, err = GetPluginHistory("", configtypes.TargetUnknown)
+	assertions.Nil(err)
+	assertions.Equal(7, len(entries))
+}
+
+func TestPluginHistoryIsKeptBesideCatalog(t *testing.T) {
+	assertions := assert.New(t)
+
+	// The catalog directory does not exist yet
+	dir := filepath.Join(t.TempDir(), "catalog")
+	t.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", dir)
+
+	recordPluginHistory(PluginHistoryOperationInstall, "", nil, &cli.PluginInfo{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.0.0"})
+	assertions.FileExists(filepath.Join(dir, pluginHistoryFileName))
+
+	entries, err := GetPluginHistory("cluster", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.Equal(1, len(entries))
+	assertions.Equal("v1.0.0", entries[0].NewVersion)
+}
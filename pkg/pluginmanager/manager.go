@@ -5,6 +5,7 @@
 package pluginmanager
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -55,6 +57,10 @@ const (
 
 var execCommand = exec.Command
 
+// commandTreeTimeout is the maximum duration of the capture of the
+// command tree of a plugin, during which its help commands are run
+var commandTreeTimeout = 30 * time.Second
+
 type DeletePluginOptions struct {
 	Target      configtypes.Target
 	PluginName  string
@@ -258,7 +264,7 @@ func discoverServerPluginsBasedOnAllCurrentContexts() ([]discovery.Discovered, e
 	var plugins []discovery.Discovered
 	var errList []error
 
-	currentContextMap, err := configlib.GetAllCurrentContextsMap()
+	currentContextMap, err := cli.GetAllCurrentContextsMap()
 	if err != nil {
 		return nil, err
 	}
@@ -891,18 +897,74 @@ func installOrUpgradePlugin(p *discovery.Discovered, version string, installTest
 		return err
 	}
 
-	plugin, err := installAndDescribePlugin(p, version, binary)
+	plugin, err := installPluginBinary(p, version, binary, installTestPlugin)
 	if err != nil {
 		return err
 	}
 
+	// The command tree is captured once the plugin root is unlocked, as
+	// running the help of every command of the plugin can take a while
+	if plugin.CommandTree == nil {
+		captureCommandTree(p.ContextName, plugin)
+	}
+	return nil
+}
+
+// installPluginBinary installs the plugin binary and records the plugin in
+// the catalog while holding the plugin root lock
+func installPluginBinary(p *discovery.Discovered, version string, binary []byte, installTestPlugin bool) (*cli.PluginInfo, error) {
+	// Prevent concurrent tanzu processes from writing the same
+	// plugin binaries or updating the catalog at the same time
+	unlock, err := catalog.LockPluginRoot()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	previous := getInstalledPluginFromCatalog(p)
+
+	plugin, err := installAndDescribePlugin(p, version, binary)
+	if err != nil {
+		return nil, err
+	}
+	// The command tree of the same binary does not need to be captured again
+	if previous != nil && previous.InstallationPath == plugin.InstallationPath {
+		plugin.CommandTree = previous.CommandTree
+	}
+
 	if installTestPlugin {
-		if err := doInstallTestPlugin(p, plugin.InstallationPath, version); err != nil {
-			return err
+		if err := doInstallTestPlugin(p, plugin, version); err != nil {
+			return nil, err
 		}
 	}
 
-	return updatePluginInfoAndInitializePlugin(p, plugin)
+	if err := updatePluginInfoAndInitializePlugin(p, plugin); err != nil {
+		return nil, err
+	}
+
+	// Context-scope plugins are installed when synchronizing the plugins of the contexts
+	operation := PluginHistoryOperationInstall
+	if p.ContextName != "" {
+		operation = PluginHistoryOperationSync
+	} else if previous != nil && previous.Version != plugin.Version {
+		operation = PluginHistoryOperationUpgrade
+	}
+	recordPluginHistory(operation, p.ContextName, previous, plugin)
+	cli.ClearCompletionCache(plugin.Name)
+	return plugin, nil
+}
+
+// getInstalledPluginFromCatalog returns the installed plugin of the catalog
+// the discovered plugin is installed into, or nil if it is not installed
+func getInstalledPluginFromCatalog(p *discovery.Discovered) *cli.PluginInfo {
+	c, err := catalog.NewContextCatalog(p.ContextName)
+	if err != nil {
+		return nil
+	}
+	if pd, exists := c.Get(catalog.PluginNameTarget(p.Name, p.Target)); exists {
+		return &pd
+	}
+	return nil
 }
 
 func fetchAndVerifyPlugin(p *discovery.Discovered, version string) ([]byte, error) {
@@ -954,6 +1016,7 @@ func installAndDescribePlugin(p *discovery.Discovered, version string, binary []
 		return nil, errors.Wrapf(err, "could not unmarshal plugin %q description", p.Name)
 	}
 	plugin.InstallationPath = pluginPath
+	plugin.Digest = fmt.Sprintf("%x", sha256.Sum256(binary))
 	plugin.Discovery = p.Source
 	plugin.DiscoveredRecommendedVersion = p.RecommendedVersion
 	plugin.Target = p.Target
@@ -966,7 +1029,84 @@ func installAndDescribePlugin(p *discovery.Discovered, version string, binary []
 	return &plugin, nil
 }
 
-func doInstallTestPlugin(p *discovery.Discovered, pluginPath, version string) error {
+// describeCommandTree captures the command tree of a plugin binary, so that
+// its help and completions are available without running the plugin.
+// The command tree is optional and nil is returned if it cannot be captured
+// within commandTreeTimeout, the plugin then being run for its completions.
+func describeCommandTree(pluginName, pluginPath string) *cli.CommandNode {
+	deadline := time.Now().Add(commandTreeTimeout)
+	tree, help, err := cli.CaptureCommandTree(pluginName, func(args ...string) ([]byte, error) {
+		timeout := time.Until(deadline)
+		if timeout <= 0 {
+			return nil, errors.Errorf("the capture did not complete within %v", commandTreeTimeout)
+		}
+		return runWithTimeout(execCommand(pluginPath, args...), timeout)
+	})
+	if err == nil {
+		err = cli.SaveCommandHelp(pluginPath, help)
+	}
+	if err != nil {
+		log.V(6).Infof("unable to capture the command tree of plugin %q: %v", pluginName, err)
+		return nil
+	}
+	return tree
+}
+
+// captureCommandTree captures the command tree of an installed plugin and
+// stores it in the catalog of the context. The plugin root is only locked to
+// update the catalog, unless the plugin was replaced in the meantime.
+func captureCommandTree(contextName string, plugin *cli.PluginInfo) {
+	tree := describeCommandTree(plugin.Name, plugin.InstallationPath)
+	if tree == nil {
+		return
+	}
+
+	unlock, err := catalog.LockPluginRoot()
+	if err != nil {
+		log.V(6).Infof("unable to store the command tree of plugin %q: %v", plugin.Name, err)
+		return
+	}
+	defer unlock()
+
+	c, err := catalog.NewContextCatalog(contextName)
+	if err != nil {
+		return
+	}
+	pd, exists := c.Get(catalog.PluginNameTarget(plugin.Name, plugin.Target))
+	if !exists || pd.InstallationPath != plugin.InstallationPath {
+		return
+	}
+	pd.CommandTree = tree
+	if err := c.Upsert(&pd); err != nil {
+		log.V(6).Infof("unable to store the command tree of plugin %q: %v", plugin.Name, err)
+		return
+	}
+	plugin.CommandTree = tree
+}
+
+// runWithTimeout runs a command and returns its output. The command is
+// killed if it does not complete within the timeout.
+func runWithTimeout(cmd *exec.Cmd, timeout time.Duration) ([]byte, error) {
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		return stdout.Bytes(), err
+	case <-time.After(timeout):
+		_ = cmd.Process.Kill()
+		return nil, errors.Errorf("%q timed out after %v", strings.Join(cmd.Args, " "), timeout)
+	}
+}
+
+func doInstallTestPlugin(p *discovery.Discovered, plugin *cli.PluginInfo, version string) error {
 	log.Infof("Installing test plugin for '%v:%v'", p.Name, version)
 	binary, err := p.Distribution.FetchTest(version, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
@@ -976,12 +1116,13 @@ func doInstallTestPlugin(p *discovery.Discovered, pluginPath, version string) er
 		log.Infof("  ... skipped: %s", err.Error())
 		return nil
 	}
-	testPluginPath := cli.TestPluginPathFromPluginPath(pluginPath)
+	testPluginPath := cli.TestPluginPathFromPluginPath(plugin.InstallationPath)
 
 	err = os.WriteFile(testPluginPath, binary, 0755)
 	if err != nil {
 		return errors.Wrap(err, "error while saving test plugin binary")
 	}
+	plugin.TestDigest = fmt.Sprintf("%x", sha256.Sum256(binary))
 	return nil
 }
 
@@ -1072,10 +1213,16 @@ func doDeletePluginFromCatalog(pluginName string, target configtypes.Target, cat
 			continue
 		}
 
-		err = c.Delete(catalog.PluginNameTarget(pluginName, target))
+		pluginNameTarget := catalog.PluginNameTarget(pluginName, target)
+		previous, exists := c.Get(pluginNameTarget)
+		err = c.Delete(pluginNameTarget)
 		if err != nil {
 			return fmt.Errorf("plugin %q could not be deleted from cache", pluginName)
 		}
+		if exists {
+			recordPluginHistory(PluginHistoryOperationDelete, n, &previous, nil)
+			cli.ClearCompletionCache(previous.Name)
+		}
 	}
 
 	return nil
@@ -1115,7 +1262,7 @@ func SyncPlugins() error {
 
 	errList := make([]error, 0)
 	for idx := range plugins {
-		if plugins[idx].Status == common.PluginStatusNotInstalled {
+		if needsSync(&plugins[idx]) {
 			installed = true
 			p := plugins[idx]
 			err = InstallPluginFromContext(p.Name, p.RecommendedVersion, p.Target, p.ContextName)
@@ -1137,6 +1284,120 @@ func SyncPlugins() error {
 	return nil
 }
 
+// GetContextPluginsToSync returns the plugins recommended by the active contexts which
+// are not installed, or whose recommended version has changed since they were installed
+func GetContextPluginsToSync() ([]discovery.Discovered, error) {
+	plugins, err := DiscoverServerPlugins()
+	if err != nil {
+		return nil, err
+	}
+	installedPlugins, err := pluginsupplier.GetInstalledServerPlugins()
+	if err != nil {
+		return nil, err
+	}
+	setAvailablePluginsStatus(plugins, installedPlugins)
+
+	var toSync []discovery.Discovered
+	for i := range plugins {
+		if needsSync(&plugins[i]) {
+			toSync = append(toSync, plugins[i])
+		}
+	}
+	return toSync, nil
+}
+
+// needsSync returns true if a discovered plugin is not installed, or if it is a plugin
+// of a context whose recommended version has changed since it was installed
+func needsSync(p *discovery.Discovered) bool {
+	return p.Status == common.PluginStatusNotInstalled ||
+		(p.Scope == common.PluginScopeContext && p.Status == common.PluginStatusUpdateAvailable)
+}
+
+// PluginUpdate describes an installed standalone plugin for which a newer
+// version is recommended by a plugin discovery
+type PluginUpdate struct {
+	Name               string             `yaml:"name"`
+	Target             configtypes.Target `yaml:"target"`
+	InstalledVersion   string             `yaml:"installedVersion"`
+	RecommendedVersion string             `yaml:"recommendedVersion"`
+}
+
+// GetPluginUpdates returns the installed standalone plugins for which the
+// cached inventories of the configured discoveries recommend a newer version.
+// The discovery registries are never contacted, so the result is only as
+// recent as the cached inventories. Discoveries without a cached inventory
+// are ignored.
+func GetPluginUpdates() ([]PluginUpdate, error) {
+	installedPlugins, err := pluginsupplier.GetInstalledStandalonePlugins()
+	if err != nil {
+		return nil, err
+	}
+	if len(installedPlugins) == 0 {
+		return nil, nil
+	}
+	discoveries, err := getPluginDiscoveries()
+	if err != nil && len(discoveries) == 0 {
+		return nil, err
+	}
+
+	// The first discovery providing a plugin wins, as it does when installing plugins
+	recommended := make(map[string]string)
+	for _, d := range discoveries {
+		if d.OCI == nil {
+			continue
+		}
+		plugins, err := discovery.ListCachedPlugins(d.OCI.Name, d.OCI.Image)
+		if err != nil {
+			continue
+		}
+		for i := range plugins {
+			key := fmt.Sprintf("%s:%s", plugins[i].Name, plugins[i].Target)
+			if _, exists := recommended[key]; !exists {
+				recommended[key] = plugins[i].RecommendedVersion
+			}
+		}
+	}
+
+	var updates []PluginUpdate
+	for i := range installedPlugins {
+		version := recommended[fmt.Sprintf("%s:%s", installedPlugins[i].Name, installedPlugins[i].Target)]
+		if !semver.IsValid(version) || !semver.IsValid(installedPlugins[i].Version) {
+			continue
+		}
+		if semver.Compare(version, installedPlugins[i].Version) > 0 {
+			updates = append(updates, PluginUpdate{
+				Name:               installedPlugins[i].Name,
+				Target:             installedPlugins[i].Target,
+				InstalledVersion:   installedPlugins[i].Version,
+				RecommendedVersion: version,
+			})
+		}
+	}
+	return updates, nil
+}
+
+// GetRecommendedCLIVersion returns the version of the CLI recommended by the
+// cached inventories of the configured discoveries. The first discovery
+// recommending a version wins. The discovery registries are never contacted
+// and an empty version is returned if no cached inventory recommends a version.
+func GetRecommendedCLIVersion() (string, error) {
+	discoveries, err := getPluginDiscoveries()
+	if err != nil && len(discoveries) == 0 {
+		return "", err
+	}
+	for _, d := range discoveries {
+		if d.OCI == nil {
+			continue
+		}
+		version, err := discovery.GetCachedCLIRecommendedVersion(d.OCI.Name, d.OCI.Image)
+		if err != nil || version == "" {
+			continue
+		}
+		return version, nil
+	}
+	return "", nil
+}
+
 // InstallPluginsFromLocalSource installs plugin from local source directory
 // nolint: gocyclo
 func InstallPluginsFromLocalSource(pluginName, version string, target configtypes.Target, localPath string, installTestPlugin bool) error {
@@ -1257,6 +1518,12 @@ func discoverPluginsFromLocalSource(localPath string) ([]discovery.Discovered, e
 
 // Clean deletes all plugins and tests.
 func Clean() error {
+	unlock, err := catalog.LockPluginRoot()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if err := catalog.CleanCatalogCache(); err != nil {
 		return errors.Errorf("Failed to clean the catalog cache %v", err)
 	}
@@ -1480,6 +1747,30 @@ func FindVersion(recommendedPluginVersion, requestedVersion string) string {
 	return requestedVersion
 }
 
+// RefreshDiscoverySourceCache forces the cached inventory of the
+// standalone discovery source to be updated
+func RefreshDiscoverySourceCache(discoveryName string) error {
+	discoveries, err := getPluginDiscoveries()
+	if err != nil {
+		return err
+	}
+	for _, pd := range discoveries {
+		if !discovery.CheckDiscoveryName(pd, discoveryName) {
+			continue
+		}
+		d, err := discovery.CreateDiscoveryFromV1alpha1(pd, nil)
+		if err != nil {
+			return err
+		}
+		cachedDiscovery, ok := d.(discovery.CachedDiscovery)
+		if !ok {
+			return errors.Errorf("discovery source %q does not cache its plugin inventory", discoveryName)
+		}
+		return cachedDiscovery.RefreshCache()
+	}
+	return errors.Errorf("discovery source %q does not exist", discoveryName)
+}
+
 // getPluginDiscoveries returns the plugin discoveries found in the configuration file.
 func getPluginDiscoveries() ([]configtypes.PluginDiscovery, error) {
 	var testDiscoveries []configtypes.PluginDiscovery
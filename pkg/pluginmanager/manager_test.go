@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
@@ -142,6 +144,8 @@ func Test_InstallPlugin_InstalledPlugins_No_Central_Repo(t *testing.T) {
 	assertions.Nil(err)
 	assertions.Equal(1, len(installedPlugins))
 	assertions.Equal("login", installedPlugins[0].Name)
+	// The command tree captured after the installation is stored in the catalog
+	assertions.NotNil(installedPlugins[0].CommandTree)
 
 	// Try installing cluster plugin with no context-type
 	err = InstallStandalonePlugin("cluster", "v0.2.0", configtypes.TargetUnknown)
@@ -1764,3 +1768,141 @@ func TestMergeDuplicatePluginsWithReplacedVersion(t *testing.T) {
 	assertions.Equal(1, len(mergedPlugins))
 	assertions.Equal(expectedPlugin, mergedPlugins[0])
 }
+
+func TestNeedsSync(t *testing.T) {
+	assertions := assert.New(t)
+
+	assertions.True(needsSync(&discovery.Discovered{Scope: common.PluginScopeContext, Status: common.PluginStatusNotInstalled}))
+	assertions.True(needsSync(&discovery.Discovered{Scope: common.PluginScopeContext, Status: common.PluginStatusUpdateAvailable}))
+	assertions.False(needsSync(&discovery.Discovered{Scope: common.PluginScopeContext, Status: common.PluginStatusInstalled}))
+	assertions.True(needsSync(&discovery.Discovered{Scope: common.PluginScopeStandalone, Status: common.PluginStatusNotInstalled}))
+	assertions.False(needsSync(&discovery.Discovered{Scope: common.PluginScopeStandalone, Status: common.PluginStatusUpdateAvailable}))
+}
+
+func TestGetPluginUpdates(t *testing.T) {
+	assertions := assert.New(t)
+	defer setupLocalDistroForTesting()()
+
+	// A single OCI discovery whose inventory is only available from the cache
+	err := os.Setenv(constants.ConfigVariableAdditionalDiscoveryForTesting, "localhost:9876/my/discovery/image:v1")
+	assertions.Nil(err)
+	defer os.Unsetenv(constants.ConfigVariableAdditionalDiscoveryForTesting)
+	err = os.Setenv(constants.ConfigVariablePreReleasePluginRepoImage, PreReleasePluginRepoImageBypass)
+	assertions.Nil(err)
+	defer os.Unsetenv(constants.ConfigVariablePreReleasePluginRepoImage)
+
+	fakeInstallPluginVersion(t, "cluster", "v1.0.0", configtypes.TargetK8s)
+	fakeInstallPluginVersion(t, "isolated-cluster", "v0.1.0", configtypes.TargetGlobal)
+
+	// Without a cached inventory, no update is found
+	updates, err := GetPluginUpdates()
+	assertions.Nil(err)
+	assertions.Empty(updates)
+
+	dataDir := filepath.Join(common.DefaultCacheDir, "plugin_inventory", "test_0")
+	assertions.Nil(os.MkdirAll(dataDir, 0o755))
+	inventory := plugininventory.NewSQLiteInventory(filepath.Join(dataDir, plugininventory.SQliteDBFileName), "")
+	assertions.Nil(inventory.CreateSchema())
+	for _, p := range []struct {
+		name     string
+		target   configtypes.Target
+		versions []string
+	}{
+		{"cluster", configtypes.TargetK8s, []string{"v1.0.0", "v1.1.0"}},
+		{"isolated-cluster", configtypes.TargetGlobal, []string{"v0.1.0"}},
+	} {
+		entry := &plugininventory.PluginInventoryEntry{
+			Name:               p.name,
+			Target:             p.target,
+			Description:        "plugin " + p.name,
+			Publisher:          "tkg",
+			Vendor:             "vmware",
+			RecommendedVersion: p.versions[len(p.versions)-1],
+			Artifacts:          distribution.Artifacts{},
+		}
+		for _, v := range p.versions {
+			entry.Artifacts[v] = distribution.ArtifactList{
+				{Image: "linux/amd64/" + p.name + ":" + v, Digest: "0000", OS: "linux", Arch: "amd64"},
+			}
+		}
+		assertions.Nil(inventory.InsertPlugin(entry))
+	}
+	assertions.Nil(os.WriteFile(filepath.Join(dataDir, "digest.1234"), []byte("localhost:9876/my/discovery/image:v1"), 0o600))
+
+	// Only the plugin with a newer recommended version is reported
+	updates, err = GetPluginUpdates()
+	assertions.Nil(err)
+	assertions.Equal([]PluginUpdate{
+		{Name: "cluster", Target: configtypes.TargetK8s, InstalledVersion: "v1.0.0", RecommendedVersion: "v1.1.0"},
+	}, updates)
+}
+
+func TestGetRecommendedCLIVersion(t *testing.T) {
+	assertions := assert.New(t)
+	defer setupLocalDistroForTesting()()
+
+	// A single OCI discovery whose inventory is only available from the cache
+	err := os.Setenv(constants.ConfigVariableAdditionalDiscoveryForTesting, "localhost:9876/my/discovery/image:v1")
+	assertions.Nil(err)
+	defer os.Unsetenv(constants.ConfigVariableAdditionalDiscoveryForTesting)
+	err = os.Setenv(constants.ConfigVariablePreReleasePluginRepoImage, PreReleasePluginRepoImageBypass)
+	assertions.Nil(err)
+	defer os.Unsetenv(constants.ConfigVariablePreReleasePluginRepoImage)
+
+	// Without a cached inventory, no version is recommended
+	version, err := GetRecommendedCLIVersion()
+	assertions.Nil(err)
+	assertions.Empty(version)
+
+	dataDir := filepath.Join(common.DefaultCacheDir, "plugin_inventory", "test_0")
+	assertions.Nil(os.MkdirAll(dataDir, 0o755))
+	inventory := plugininventory.NewSQLiteInventory(filepath.Join(dataDir, plugininventory.SQliteDBFileName), "")
+	assertions.Nil(inventory.CreateSchema())
+	assertions.Nil(os.WriteFile(filepath.Join(dataDir, "digest.1234"), []byte("localhost:9876/my/discovery/image:v1"), 0o600))
+
+	// The cached inventory does not recommend a version
+	version, err = GetRecommendedCLIVersion()
+	assertions.Nil(err)
+	assertions.Empty(version)
+
+	assertions.Nil(inventory.SetCLIRecommendedVersion("v1.1.0"))
+	version, err = GetRecommendedCLIVersion()
+	assertions.Nil(err)
+	assertions.Equal("v1.1.0", version)
+}
+
+func TestRunWithTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	output, err := runWithTimeout(exec.Command("echo", "help"), time.Minute)
+	assert.Nil(err)
+	assert.Equal("help\n", string(output))
+
+	_, err = runWithTimeout(exec.Command("sleep", "60"), 100*time.Millisecond)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "timed out after 100ms")
+}
+
+func TestDescribeCommandTreeTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	// Every help command completes within the timeout, but not all of them
+	pluginPath := filepath.Join(t.TempDir(), "v1.0.0_digest_global")
+	script := `#!/bin/sh
+if [ "$1" = "-h" ]; then
+  printf 'Usage:\n  tanzu slow [command]\n\nAvailable Commands:\n  one  One\n  two  Two\n  three  Three\n'
+else
+  sleep 0.2
+  printf 'Usage:\n  tanzu slow %s\n' "$1"
+fi
+`
+	assert.Nil(os.WriteFile(pluginPath, []byte(script), 0755))
+
+	tree := describeCommandTree("slow", pluginPath)
+	assert.NotNil(tree)
+	assert.Equal(3, len(tree.Commands))
+
+	commandTreeTimeout = 500 * time.Millisecond
+	defer func() { commandTreeTimeout = 30 * time.Second }()
+	assert.Nil(describeCommandTree("slow", pluginPath))
+}
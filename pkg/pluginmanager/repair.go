@@ -0,0 +1,238 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+// RepairCatalog rebuilds the plugin catalog from the plugin binaries of the
+// plugin root directory. The information of every plugin is obtained by running
+// the "info" command of its binary.
+//
+// If the existing catalog can be read, the plugins remain associated with the
+// same contexts, and the plugins whose binary is missing are removed from the
+// catalog. If the catalog is missing or cannot be read, the most recent version
+// of every plugin found is restored as a stand-alone plugin.
+//
+// The repaired catalog is returned as the list of plugins associated with each
+// context, the stand-alone plugins being associated with the empty context name.
+func RepairCatalog() (map[string][]cli.PluginInfo, error) {
+	repaired, err := repairCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	// The command trees are captured once the plugin root is unlocked
+	captured := make(map[string]bool)
+	for contextName, plugins := range repaired {
+		for i := range plugins {
+			if !captured[plugins[i].InstallationPath] {
+				captured[plugins[i].InstallationPath] = true
+				captureCommandTree(contextName, &plugins[i])
+			}
+		}
+	}
+	return repaired, nil
+}
+
+// repairCatalog rebuilds the plugin catalog while holding the plugin root lock
+func repairCatalog() (map[string][]cli.PluginInfo, error) {
+	unlock, err := catalog.LockPluginRoot()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	previous, err := readCatalogForRepair()
+	if err != nil {
+		return nil, err
+	}
+
+	installed, err := describePluginBinaries()
+	if err != nil {
+		return nil, err
+	}
+	installedByPath := make(map[string]*cli.PluginInfo)
+	for i := range installed {
+		installedByPath[installed[i].InstallationPath] = &installed[i]
+	}
+
+	associations := map[string]catalog.PluginAssociation{"": {}}
+	repaired := make(map[string][]cli.PluginInfo)
+	associate := func(contextName string, pi *cli.PluginInfo) {
+		if _, exists := associations[contextName]; !exists {
+			associations[contextName] = catalog.PluginAssociation{}
+		}
+		associations[contextName].Add(catalog.PluginNameTarget(pi.Name, pi.Target), pi.InstallationPath)
+		repaired[contextName] = append(repaired[contextName], *pi)
+	}
+
+	if previous != nil {
+		for contextName, plugins := range previous {
+			for i := range plugins {
+				pi, exists := installedByPath[plugins[i].InstallationPath]
+				if !exists {
+					log.Warningf("the binary of plugin %q is missing, install the plugin again", plugins[i].Name)
+					continue
+				}
+				// The discovery information is only known at installation time
+				pi.Discovery = plugins[i].Discovery
+				pi.DiscoveredRecommendedVersion = plugins[i].DiscoveredRecommendedVersion
+				pi.Scope = plugins[i].Scope
+				pi.Status = plugins[i].Status
+				associate(contextName, pi)
+			}
+		}
+	} else {
+		for _, pi := range latestPluginVersions(installed) {
+			pi.Scope = common.PluginScopeStandalone
+			pi.Status = common.PluginStatusInstalled
+			associate("", pi)
+		}
+	}
+
+	if err := catalog.RebuildCatalog(installed, associations); err != nil {
+		return nil, err
+	}
+	return repaired, nil
+}
+
+// readCatalogForRepair returns the plugins of the existing catalog, or nil
+// if the catalog is missing or cannot be read
+func readCatalogForRepair() (map[string][]cli.PluginInfo, error) {
+	exists, err := catalog.CatalogCacheExists()
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		log.Info("the plugin catalog is missing, the installed plugins will be restored as stand-alone plugins")
+		return nil, nil
+	}
+
+	plugins, err := catalog.ListPluginsByContext()
+	if err != nil {
+		if !catalog.IsInvalidCatalogError(err) {
+			return nil, err
+		}
+		log.Warningf("the plugin catalog cannot be read, the installed plugins will be restored as stand-alone plugins: %v", err)
+		return nil, nil
+	}
+	return plugins, nil
+}
+
+// describePluginBinaries runs the "info" command of every plugin binary
+// of the plugin root directory. The binaries which cannot be described
+// are skipped.
+func describePluginBinaries() ([]cli.PluginInfo, error) {
+	pluginDirs, err := os.ReadDir(common.DefaultPluginRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "unable to read the plugin root directory")
+	}
+
+	var plugins []cli.PluginInfo
+	for _, pluginDir := range pluginDirs {
+		if !pluginDir.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(common.DefaultPluginRoot, pluginDir.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the directory of plugin %q", pluginDir.Name())
+		}
+		for _, file := range files {
+			if file.IsDir() || strings.HasPrefix(file.Name(), "test-") {
+				continue
+			}
+			path := filepath.Join(common.DefaultPluginRoot, pluginDir.Name(), file.Name())
+			pi, err := describePluginBinary(path)
+			if err != nil {
+				log.Warningf("skipping %s: %v", path, err)
+				continue
+			}
+			plugins = append(plugins, *pi)
+		}
+	}
+	return plugins, nil
+}
+
+// describePluginBinary returns the information of a plugin binary installed
+// at a path of the form <plugin-root>/<name>/<version>_<digest>_<target>
+func describePluginBinary(path string) (*cli.PluginInfo, error) {
+	fileName := strings.TrimSuffix(filepath.Base(path), exe)
+	parts := strings.Split(fileName, "_")
+	if len(parts) != 3 {
+		return nil, errors.New("not a plugin binary installed by the CLI")
+	}
+
+	bytesInfo, err := execCommand(path, "info").Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not describe plugin")
+	}
+	var plugin cli.PluginInfo
+	if err = json.Unmarshal(bytesInfo, &plugin); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal plugin description")
+	}
+
+	plugin.InstallationPath = path
+	plugin.Target = configtypes.Target(parts[2])
+	if plugin.Digest, err = fileDigest(path); err != nil {
+		return nil, err
+	}
+	if testDigest, err := fileDigest(cli.TestPluginPathFromPluginPath(path)); err == nil {
+		plugin.TestDigest = testDigest
+	}
+	return &plugin, nil
+}
+
+// latestPluginVersions returns the most recent version of every plugin name and target
+func latestPluginVersions(plugins []cli.PluginInfo) []*cli.PluginInfo {
+	latest := make(map[string]*cli.PluginInfo)
+	var keys []string
+	for i := range plugins {
+		key := catalog.PluginNameTarget(plugins[i].Name, plugins[i].Target)
+		current, exists := latest[key]
+		if !exists {
+			keys = append(keys, key)
+		}
+		if !exists || isNewerVersion(plugins[i].Version, current.Version) {
+			latest[key] = &plugins[i]
+		}
+	}
+
+	sort.Strings(keys)
+	result := make([]*cli.PluginInfo, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, latest[key])
+	}
+	return result
+}
+
+func isNewerVersion(version, other string) bool {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	o, err := semver.NewVersion(other)
+	if err != nil {
+		return true
+	}
+	return v.GreaterThan(o)
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestRepairCatalog(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupLocalDistroForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// Turn off central repo feature
+	featureArray := strings.Split(constants.FeatureDisableCentralRepositoryForTesting, ".")
+	err := configlib.SetFeature(featureArray[1], featureArray[2], "true")
+	assertions.Nil(err)
+
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetUnknown))
+	assertions.Nil(InstallStandalonePlugin("management-cluster", "v1.6.0", configtypes.TargetK8s))
+	installed, err := catalog.ListPluginsByContext()
+	assertions.Nil(err)
+	assertions.Equal(2, len(installed[""]))
+
+	// A corrupted catalog is rebuilt from the plugin binaries
+	catalogPath := filepath.Join(common.DefaultCacheDir, "catalog.yaml")
+	assertions.Nil(os.WriteFile(catalogPath, []byte("indexByPath: [\n"), 0o644))
+	_, err = catalog.ListPluginsByContext()
+	assertions.True(catalog.IsInvalidCatalogError(err))
+
+	repaired, err := RepairCatalog()
+	assertions.Nil(err)
+	assertions.Equal(2, len(repaired[""]))
+	for i := range repaired[""] {
+		assertions.Equal(common.PluginScopeStandalone, repaired[""][i].Scope)
+		assertions.NotEmpty(repaired[""][i].Digest)
+	}
+	plugins, err := catalog.ListPluginsByContext()
+	assertions.Nil(err)
+	// The discovery information of the plugins is lost with the catalog
+	pathsAndVersions := func(plugins []cli.PluginInfo) []string {
+		var result []string
+		for i := range plugins {
+			result = append(result, plugins[i].InstallationPath+"@"+plugins[i].Version)
+		}
+		return result
+	}
+	assertions.ElementsMatch(pathsAndVersions(installed[""]), pathsAndVersions(plugins[""]))
+
+	// A lost catalog is rebuilt from the plugin binaries
+	assertions.Nil(catalog.CleanCatalogCache())
+	_, err = RepairCatalog()
+	assertions.Nil(err)
+	plugins, err = catalog.ListPluginsByContext()
+	assertions.Nil(err)
+	assertions.Equal(2, len(plugins[""]))
+
+	// Plugins whose binary is missing are removed from a valid catalog
+	var mcPath string
+	for i := range plugins[""] {
+		if plugins[""][i].Name == "management-cluster" {
+			mcPath = plugins[""][i].InstallationPath
+		}
+	}
+	assertions.Nil(os.Remove(mcPath))
+	repaired, err = RepairCatalog()
+	assertions.Nil(err)
+	assertions.Equal(1, len(repaired[""]))
+	assertions.Equal("login", repaired[""][0].Name)
+
+	results, err := VerifyInstalledPlugins()
+	assertions.Nil(err)
+	for _, r := range results {
+		assertions.Equal(PluginVerificationOK, r.Status, r.Path)
+	}
+}
+
+func TestLatestPluginVersions(t *testing.T) {
+	assertions := assert.New(t)
+
+	plugins := []cli.PluginInfo{
+		{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.2.0"},
+		{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.10.0"},
+		{Name: "cluster", Target: configtypes.TargetTMC, Version: "v0.1.0"},
+		{Name: "login", Version: "v0.2.0"},
+		{Name: "login", Version: "dev"},
+	}
+	latest := latestPluginVersions(plugins)
+	assertions.Equal(3, len(latest))
+	assertions.Equal("v1.10.0", latest[0].Version)
+	assertions.Equal(configtypes.TargetTMC, latest[1].Target)
+	assertions.Equal("v0.2.0", latest[2].Version)
+}
@@ -0,0 +1,327 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// Status of an installed plugin binary after verification
+const (
+	PluginVerificationOK             = "ok"
+	PluginVerificationDigestMismatch = "digest mismatch"
+	PluginVerificationMissing        = "missing"
+	PluginVerificationOrphaned       = "orphaned"
+)
+
+// PluginVerificationResult describes the verification of an installed plugin binary
+type PluginVerificationResult struct {
+	// Name is the name of the plugin
+	Name string `json:"name" yaml:"name"`
+	// Target is the target of the plugin
+	Target configtypes.Target `json:"target" yaml:"target"`
+	// Version is the installed version of the plugin
+	Version string `json:"version" yaml:"version"`
+	// ContextName is the context the plugin is installed for,
+	// empty for a stand-alone plugin
+	ContextName string `json:"context" yaml:"context"`
+	// Path is the location of the verified binary
+	Path string `json:"path" yaml:"path"`
+	// TestPlugin tells whether the binary is the test plugin binary
+	TestPlugin bool `json:"testPlugin" yaml:"testPlugin"`
+	// Status is the result of the verification
+	Status string `json:"status" yaml:"status"`
+	// Discovery is the discovery source the plugin was installed from
+	Discovery string `json:"-" yaml:"-"`
+}
+
+// VerifyInstalledPlugins hashes the binaries of all the installed plugins and
+// their test plugins and compares the hashes with the digests recorded in the
+// catalog. It also reports the binaries of the plugin root which are not known
+// to the catalog.
+func VerifyInstalledPlugins() ([]PluginVerificationResult, error) {
+	pluginsByContext, err := catalog.ListPluginsByContext()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []PluginVerificationResult
+	for contextName, plugins := range pluginsByContext {
+		for i := range plugins {
+			results = append(results, verifyInstalledPlugin(&plugins[i], contextName)...)
+		}
+	}
+
+	orphans, err := findOrphanedPluginBinaries()
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, orphans...)
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		if results[i].Target != results[j].Target {
+			return results[i].Target < results[j].Target
+		}
+		if results[i].ContextName != results[j].ContextName {
+			return results[i].ContextName < results[j].ContextName
+		}
+		return results[i].Path < results[j].Path
+	})
+	return results, nil
+}
+
+func verifyInstalledPlugin(pi *cli.PluginInfo, contextName string) []PluginVerificationResult {
+	newResult := func(path string, testPlugin bool, status string) PluginVerificationResult {
+		return PluginVerificationResult{
+			Name:        pi.Name,
+			Target:      pi.Target,
+			Version:     pi.Version,
+			ContextName: contextName,
+			Path:        path,
+			TestPlugin:  testPlugin,
+			Status:      status,
+			Discovery:   pi.Discovery,
+		}
+	}
+
+	expectedDigest := pi.Digest
+	if expectedDigest == "" {
+		// Plugins installed before the digest was recorded in the catalog
+		// have the digest as part of their installation path
+		expectedDigest = digestFromInstallationPath(pi.InstallationPath)
+	}
+	results := []PluginVerificationResult{
+		newResult(pi.InstallationPath, false, verifyBinaryDigest(pi.InstallationPath, expectedDigest)),
+	}
+
+	// The digest of the test plugin binary is only known if the test
+	// plugin was installed along with the plugin
+	if pi.TestDigest != "" {
+		testPluginPath := cli.TestPluginPathFromPluginPath(pi.InstallationPath)
+		results = append(results, newResult(testPluginPath, true, verifyBinaryDigest(testPluginPath, pi.TestDigest)))
+	}
+	return results
+}
+
+func verifyBinaryDigest(path, expectedDigest string) string {
+	digest, err := fileDigest(path)
+	if err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			return PluginVerificationMissing
+		}
+		log.V(6).Infof("unable to hash %q: %v", path, err)
+		return PluginVerificationDigestMismatch
+	}
+	if expectedDigest != "" && digest != expectedDigest {
+		return PluginVerificationDigestMismatch
+	}
+	return PluginVerificationOK
+}
+
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// digestFromInstallationPath extracts the digest from a plugin installation
+// path of the form <plugin-root>/<name>/<version>_<digest>_<target>
+func digestFromInstallationPath(installationPath string) string {
+	fileName := strings.TrimSuffix(filepath.Base(installationPath), exe)
+	parts := strings.Split(fileName, "_")
+	if len(parts) < 3 || len(parts[1]) != sha256.Size*2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// findOrphanedPluginBinaries returns the binaries of the plugin root
+// which are not known to the catalog
+func findOrphanedPluginBinaries() ([]PluginVerificationResult, error) {
+	installationPaths, err := catalog.ListInstallationPaths()
+	if err != nil {
+		return nil, err
+	}
+	knownPaths := make(map[string]bool)
+	for _, installationPath := range installationPaths {
+		knownPaths[filepath.Clean(installationPath)] = true
+		knownPaths[filepath.Clean(cli.TestPluginPathFromPluginPath(installationPath))] = true
+	}
+
+	pluginDirs, err := os.ReadDir(common.DefaultPluginRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "unable to read the plugin root directory")
+	}
+
+	var orphans []PluginVerificationResult
+	for _, pluginDir := range pluginDirs {
+		if !pluginDir.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(common.DefaultPluginRoot, pluginDir.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the directory of plugin %q", pluginDir.Name())
+		}
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+			path := filepath.Join(common.DefaultPluginRoot, pluginDir.Name(), file.Name())
+			if knownPaths[path] {
+				continue
+			}
+			orphans = append(orphans, PluginVerificationResult{
+				Name:       pluginDir.Name(),
+				Path:       path,
+				TestPlugin: strings.HasPrefix(file.Name(), "test-"),
+				Status:     PluginVerificationOrphaned,
+			})
+		}
+	}
+	return orphans, nil
+}
+
+// RepairPlugins reinstalls the plugins whose binaries are missing or do not
+// match their recorded digest from the discovery source they were installed from.
+// Orphaned binaries are not modified.
+func RepairPlugins(results []PluginVerificationResult) error {
+	type pluginToRepair struct {
+		result         PluginVerificationResult
+		withTestPlugin bool
+	}
+	// A plugin is reinstalled once even if both its binary and
+	// its test plugin binary need to be repaired
+	var keys []string
+	toRepair := make(map[string]*pluginToRepair)
+	for _, r := range results {
+		if r.Status != PluginVerificationDigestMismatch && r.Status != PluginVerificationMissing {
+			continue
+		}
+		key := r.ContextName + "/" + catalog.PluginNameTarget(r.Name, r.Target)
+		if _, exists := toRepair[key]; !exists {
+			toRepair[key] = &pluginToRepair{result: r}
+			keys = append(keys, key)
+		}
+		if r.TestPlugin {
+			toRepair[key].withTestPlugin = true
+		}
+	}
+
+	var errList []error
+	for _, key := range keys {
+		p := toRepair[key]
+		if err := reinstallPlugin(&p.result, p.withTestPlugin); err != nil {
+			errList = append(errList, errors.Wrapf(err, "unable to repair plugin %q", p.result.Name))
+		}
+	}
+	if len(errList) != 0 {
+		msgs := make([]string, len(errList))
+		for i := range errList {
+			msgs[i] = errList[i].Error()
+		}
+		return errors.New(strings.Join(msgs, "\n"))
+	}
+	return nil
+}
+
+// reinstallPlugin installs the same version of the plugin again
+// from the discovery source it was originally installed from
+func reinstallPlugin(r *PluginVerificationResult, withTestPlugin bool) error {
+	var availablePlugins []discovery.Discovered
+	var err error
+	if configlib.IsFeatureActivated(constants.FeatureDisableCentralRepositoryForTesting) {
+		availablePlugins, err = AvailablePlugins()
+	} else {
+		var discoveries []configtypes.PluginDiscovery
+		discoveries, err = GetDiscoverySourcesForContext(r.ContextName)
+		if err != nil {
+			return err
+		}
+		availablePlugins, err = discoverSpecificPlugins(discoveries, &discovery.PluginDiscoveryCriteria{
+			Name:    r.Name,
+			Target:  r.Target,
+			Version: r.Version,
+			OS:      runtime.GOOS,
+			Arch:    runtime.GOARCH,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range availablePlugins {
+		p := &availablePlugins[i]
+		if p.Name != r.Name || p.Target != r.Target || !isFromDiscoverySource(p, r.Discovery) {
+			continue
+		}
+		p.ContextName = r.ContextName
+		return installOrUpgradePlugin(p, r.Version, withTestPlugin)
+	}
+	return errors.Errorf("unable to find version %s of the plugin in discovery source %q", r.Version, r.Discovery)
+}
+
+// GetDiscoverySourcesForContext returns the discovery sources of the context
+// or the stand-alone discovery sources if the context name is empty
+func GetDiscoverySourcesForContext(contextName string) ([]configtypes.PluginDiscovery, error) {
+	if contextName == "" {
+		return getPluginDiscoveries()
+	}
+	context, err := configlib.GetContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	discoveries := append([]configtypes.PluginDiscovery{}, context.DiscoverySources...)
+	return append(discoveries, defaultDiscoverySourceBasedOnContext(context)...), nil
+}
+
+// isFromDiscoverySource returns true if the plugin was discovered from the
+// discovery source, or if the discovery source is unknown.
+// The source of a plugin found in multiple discoveries is of the form "source1/source2".
+func isFromDiscoverySource(p *discovery.Discovered, discoverySource string) bool {
+	if discoverySource == "" {
+		return true
+	}
+	sources := strings.Split(p.Source, "/")
+	for _, source := range strings.Split(discoverySource, "/") {
+		for _, s := range sources {
+			if s == source {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func findVerificationResult(results []PluginVerificationResult, name string, target configtypes.Target) *PluginVerificationResult {
+	for i := range results {
+		if results[i].Name == name && results[i].Target == target && !results[i].TestPlugin && results[i].Status != PluginVerificationOrphaned {
+			return &results[i]
+		}
+	}
+	return nil
+}
+
+func TestVerifyAndRepairInstalledPlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupLocalDistroForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// Turn off central repo feature
+	featureArray := strings.Split(constants.FeatureDisableCentralRepositoryForTesting, ".")
+	err := configlib.SetFeature(featureArray[1], featureArray[2], "true")
+	assertions.Nil(err)
+
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetUnknown))
+	assertions.Nil(InstallStandalonePlugin("management-cluster", "v1.6.0", configtypes.TargetK8s))
+
+	results, err := VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(2, len(results))
+	for _, r := range results {
+		assertions.Equal(PluginVerificationOK, r.Status, r.Path)
+	}
+
+	// Tamper with a binary, remove another one and add an unknown binary
+	login := findVerificationResult(results, "login", configtypes.TargetUnknown)
+	assertions.NotNil(login)
+	assertions.Nil(os.WriteFile(login.Path, []byte("tampered"), 0o755))
+	mc := findVerificationResult(results, "management-cluster", configtypes.TargetK8s)
+	assertions.NotNil(mc)
+	assertions.Nil(os.Remove(mc.Path))
+	orphanPath := filepath.Join(common.DefaultPluginRoot, "login", "v0.1.0_unknown_")
+	assertions.Nil(os.WriteFile(orphanPath, []byte("orphan"), 0o755))
+
+	results, err = VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(3, len(results))
+	assertions.Equal(PluginVerificationDigestMismatch, findVerificationResult(results, "login", configtypes.TargetUnknown).Status)
+	assertions.Equal(PluginVerificationMissing, findVerificationResult(results, "management-cluster", configtypes.TargetK8s).Status)
+	var orphan *PluginVerificationResult
+	for i := range results {
+		if results[i].Status == PluginVerificationOrphaned {
+			orphan = &results[i]
+		}
+	}
+	assertions.NotNil(orphan)
+	assertions.Equal(orphanPath, orphan.Path)
+
+	// Repair the plugins; orphaned binaries are left untouched
+	assertions.Nil(RepairPlugins(results))
+	results, err = VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(PluginVerificationOK, findVerificationResult(results, "login", configtypes.TargetUnknown).Status)
+	assertions.Equal(PluginVerificationOK, findVerificationResult(results, "management-cluster", configtypes.TargetK8s).Status)
+	_, err = os.Stat(orphanPath)
+	assertions.Nil(err)
+}
+
+func TestDigestFromInstallationPath(t *testing.T) {
+	assertions := assert.New(t)
+
+	digest := strings.Repeat("a1", 32)
+	assertions.Equal(digest, digestFromInstallationPath("/plugins/cluster/v1.0.0_"+digest+"_kubernetes"))
+	assertions.Equal(digest, digestFromInstallationPath("/plugins/login/v1.0.0_"+digest+"_.exe"))
+	assertions.Equal("", digestFromInstallationPath("/plugins/cluster/v1.0.0"))
+	assertions.Equal("", digestFromInstallationPath("/plugins/cluster/v1.0.0_1234_kubernetes"))
+}
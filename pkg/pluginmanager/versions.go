@@ -0,0 +1,115 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+)
+
+// GetInstalledPluginVersions returns the versions of the plugin which are
+// installed side by side, sorted from the oldest to the most recent version.
+// The versions whose binary has been removed from the plugin root are ignored.
+func GetInstalledPluginVersions(pluginName string, target configtypes.Target) ([]cli.PluginInfo, error) {
+	installations, err := catalog.ListPluginInstallations(pluginName, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []cli.PluginInfo
+	for i := range installations {
+		if _, err := os.Stat(installations[i].InstallationPath); err == nil {
+			plugins = append(plugins, installations[i])
+		}
+	}
+	sort.SliceStable(plugins, func(i, j int) bool {
+		if plugins[i].Target != plugins[j].Target {
+			return plugins[i].Target < plugins[j].Target
+		}
+		return isNewerVersion(plugins[j].Version, plugins[i].Version)
+	})
+	return plugins, nil
+}
+
+// UsePluginVersion makes an installed version of a stand-alone plugin the
+// active one without downloading it again. The plugin root is locked for the
+// whole switch so that it does not interleave with another plugin operation.
+func UsePluginVersion(pluginName, version string, target configtypes.Target) error {
+	unlock, err := catalog.LockPluginRoot()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	plugin, err := findInstalledPluginVersion(pluginName, version, target)
+	if err != nil {
+		return err
+	}
+	previous, err := activateStandalonePlugin(plugin)
+	if err != nil {
+		return err
+	}
+	recordPluginHistory(PluginHistoryOperationUse, "", previous, plugin)
+	return nil
+}
+
+// findInstalledPluginVersion returns the installed plugin of the given version
+func findInstalledPluginVersion(pluginName, version string, target configtypes.Target) (*cli.PluginInfo, error) {
+	installed, err := GetInstalledPluginVersions(pluginName, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []cli.PluginInfo
+	for i := range installed {
+		if installed[i].Version == version {
+			matched = append(matched, installed[i])
+		}
+	}
+	if len(matched) == 0 {
+		return nil, errors.Errorf("version %s of plugin '%v' is not installed. Use 'tanzu plugin install %s --version %s' to install it", version, pluginName, pluginName, version)
+	}
+	if len(matched) > 1 {
+		return nil, errors.Errorf("unable to uniquely identify plugin '%v'. Please specify correct Target(kubernetes[k8s]/mission-control[tmc]) of the plugin with `--target` flag", pluginName)
+	}
+	return &matched[0], nil
+}
+
+// activateStandalonePlugin makes the installed plugin the active stand-alone
+// plugin and returns the previously active stand-alone plugin, if any
+func activateStandalonePlugin(plugin *cli.PluginInfo) (*cli.PluginInfo, error) {
+	plugin.Scope = common.PluginScopeStandalone
+
+	c, err := catalog.NewContextCatalog("")
+	if err != nil {
+		return nil, err
+	}
+	var previous *cli.PluginInfo
+	if pd, exists := c.Get(catalog.PluginNameTarget(plugin.Name, plugin.Target)); exists {
+		previous = &pd
+	}
+	if err := c.Upsert(plugin); err != nil {
+		return nil, err
+	}
+	if err := config.ConfigureDefaultFeatureFlagsIfMissing(plugin.DefaultFeatureFlags); err != nil {
+		log.Infof("could not configure default featureflags for the plugin: %v", err.Error())
+	}
+
+	// A plugin installed for the current context takes precedence over the stand-alone plugin
+	if active, err := pluginsupplier.GetInstalledPlugin(plugin.Name, plugin.Target); err == nil && active.InstallationPath != plugin.InstallationPath {
+		log.Warningf("version %s of plugin '%v' is installed for the current context and is used instead of version %s while the context is active", active.Version, plugin.Name, plugin.Version)
+	}
+	return previous, nil
+}
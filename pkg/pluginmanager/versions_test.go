@@ -0,0 +1,89 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func fakeInstallPluginVersion(t *testing.T, name, version string, target configtypes.Target) {
+	path := filepath.Join(common.DefaultPluginRoot, name, version+"_digest_"+string(target))
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.Nil(t, os.WriteFile(path, []byte(version), 0o755))
+
+	c, err := catalog.NewContextCatalog("")
+	assert.Nil(t, err)
+	assert.Nil(t, c.Upsert(&cli.PluginInfo{Name: name, Version: version, Target: target, InstallationPath: path}))
+}
+
+func TestUsePluginVersion(t *testing.T) {
+	assertions := assert.New(t)
+	defer setupLocalDistroForTesting()()
+
+	fakeInstallPluginVersion(t, "cluster", "v1.10.0", configtypes.TargetK8s)
+	fakeInstallPluginVersion(t, "cluster", "v1.2.0", configtypes.TargetK8s)
+	fakeInstallPluginVersion(t, "cluster", "v1.2.0", configtypes.TargetTMC)
+
+	// All installed versions are kept side by side
+	installed, err := GetInstalledPluginVersions("cluster", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.Equal(2, len(installed))
+	assertions.Equal("v1.2.0", installed[0].Version)
+	assertions.Equal("v1.10.0", installed[1].Version)
+
+	c, err := catalog.NewContextCatalog("")
+	assertions.Nil(err)
+	active, exists := c.Get(catalog.PluginNameTarget("cluster", configtypes.TargetK8s))
+	assertions.True(exists)
+	assertions.Equal("v1.2.0", active.Version)
+
+	// Switch to another installed version
+	assertions.Nil(UsePluginVersion("cluster", "v1.10.0", configtypes.TargetK8s))
+	c, err = catalog.NewContextCatalog("")
+	assertions.Nil(err)
+	active, _ = c.Get(catalog.PluginNameTarget("cluster", configtypes.TargetK8s))
+	assertions.Equal("v1.10.0", active.Version)
+	assertions.Equal(common.PluginScopeStandalone, active.Scope)
+
+	// The switch waits for the other plugin operations holding the plugin root lock
+	t.Setenv(constants.PluginCatalogLockTimeout, "100ms")
+	unlock, err := catalog.LockPluginRoot()
+	assertions.Nil(err)
+	err = UsePluginVersion("cluster", "v1.2.0", configtypes.TargetK8s)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "timed out after 100ms")
+	unlock()
+	c, err = catalog.NewContextCatalog("")
+	assertions.Nil(err)
+	active, _ = c.Get(catalog.PluginNameTarget("cluster", configtypes.TargetK8s))
+	assertions.Equal("v1.10.0", active.Version)
+
+	// The target is required when the version is installed for multiple targets
+	err = UsePluginVersion("cluster", "v1.2.0", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to uniquely identify plugin")
+
+	// Versions which are not installed cannot be used
+	err = UsePluginVersion("cluster", "v2.0.0", configtypes.TargetK8s)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "tanzu plugin install cluster --version v2.0.0")
+
+	// Versions whose binary was removed are ignored
+	assertions.Nil(os.Remove(installed[0].InstallationPath))
+	installed, err = GetInstalledPluginVersions("cluster", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.Equal(1, len(installed))
+	assertions.NotNil(UsePluginVersion("cluster", "v1.2.0", configtypes.TargetK8s))
+}
@@ -5,73 +5,91 @@
 package pluginsupplier
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
-	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 )
 
 // GetInstalledPlugins return the installed plugins( both standalone and server plugins )
+// The plugin catalog is read once.
 func GetInstalledPlugins() ([]cli.PluginInfo, error) {
-	plugins, err := GetInstalledServerPlugins()
+	pluginsByContext, err := catalog.ListPluginsByContext()
 	if err != nil {
 		return nil, err
 	}
-	standalonePlugins, err := GetInstalledStandalonePlugins()
+	plugins, err := serverPlugins(pluginsByContext)
 	if err != nil {
 		return nil, err
 	}
-	plugins = append(plugins, standalonePlugins...)
+	plugins = append(plugins, removeInstalledServerPlugins(pluginsByContext[""], plugins)...)
 
 	return plugins, nil
 }
 
+// GetInstalledPlugin returns the installed plugin with the given name and target
+// to be used with the current contexts. A plugin installed for the current context
+// of its target takes precedence over the same plugin installed as stand-alone,
+// so that each context can use its own version of a plugin.
+func GetInstalledPlugin(name string, target configtypes.Target) (*cli.PluginInfo, error) {
+	plugins, err := GetInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+	for i := range plugins {
+		if plugins[i].Name == name && plugins[i].Target == target {
+			return &plugins[i], nil
+		}
+	}
+	return nil, errors.Errorf("unable to find plugin '%v' for target '%s'", name, string(target))
+}
+
 // GetInstalledStandalonePlugins returns the installed standalone plugins.
 func GetInstalledStandalonePlugins() ([]cli.PluginInfo, error) {
-	standAloneCatalog, err := catalog.NewContextCatalog("")
+	pluginsByContext, err := catalog.ListPluginsByContext()
+	if err != nil {
+		return nil, err
+	}
+	installedServerPlugins, err := serverPlugins(pluginsByContext)
 	if err != nil {
 		return nil, err
 	}
-	plugins := standAloneCatalog.List()
 
 	// Any server plugin installed takes precedence over the same plugin
 	// installed as standalone.  We therefore remove those standalone
 	// plugins from the list.
-	plugins, err = removeInstalledServerPlugins(plugins)
+	return removeInstalledServerPlugins(pluginsByContext[""], installedServerPlugins), nil
+}
+
+// GetInstalledServerPlugins returns the installed server plugins.
+func GetInstalledServerPlugins() ([]cli.PluginInfo, error) {
+	pluginsByContext, err := catalog.ListPluginsByContext()
 	if err != nil {
 		return nil, err
 	}
-	return plugins, nil
+	return serverPlugins(pluginsByContext)
 }
 
-// GetInstalledServerPlugins returns the installed server plugins.
-func GetInstalledServerPlugins() ([]cli.PluginInfo, error) {
-	serverNames, err := configlib.GetAllCurrentContextsList()
+// serverPlugins returns the plugins of the current contexts
+func serverPlugins(pluginsByContext map[string][]cli.PluginInfo) ([]cli.PluginInfo, error) {
+	serverNames, err := cli.GetAllCurrentContextsList()
 	if err != nil {
 		return nil, err
 	}
 
-	var serverPlugins []cli.PluginInfo
+	var plugins []cli.PluginInfo
 	for _, serverName := range serverNames {
 		if serverName != "" {
-			serverCatalog, err := catalog.NewContextCatalog(serverName)
-			if err != nil {
-				return nil, err
-			}
-			serverPlugins = append(serverPlugins, serverCatalog.List()...)
+			plugins = append(plugins, pluginsByContext[serverName]...)
 		}
 	}
-
-	return serverPlugins, nil
+	return plugins, nil
 }
 
 // Remove any installed standalone plugin if it is also installed as a server plugin.
-func removeInstalledServerPlugins(standalone []cli.PluginInfo) ([]cli.PluginInfo, error) {
-	serverPlugins, err := GetInstalledServerPlugins()
-	if err != nil {
-		return nil, err
-	}
-
-	var installedStandalone []cli.PluginInfo
+func removeInstalledServerPlugins(standalone, serverPlugins []cli.PluginInfo) []cli.PluginInfo {
+	installedStandalone := make([]cli.PluginInfo, 0, len(standalone))
 	for i := range standalone {
 		found := false
 		for j := range serverPlugins {
@@ -84,5 +102,5 @@ func removeInstalledServerPlugins(standalone []cli.PluginInfo) ([]cli.PluginInfo
 			installedStandalone = append(installedStandalone, standalone[i])
 		}
 	}
-	return installedStandalone, nil
+	return installedStandalone
 }
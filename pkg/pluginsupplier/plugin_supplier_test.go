@@ -12,6 +12,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
 
@@ -433,6 +434,91 @@ var _ = Describe("GetInstalledPlugins (both standalone and context plugins)", fu
 	})
 })
 
+var _ = Describe("GetInstalledPlugin", func() {
+	var (
+		cdir         string
+		err          error
+		configFile   *os.File
+		configFileNG *os.File
+	)
+	const (
+		tmcContextName    = "test-tmc-context"
+		tmcUseContextName = "test-use-context"
+		pluginName        = "fake-plugin"
+	)
+	BeforeEach(func() {
+		cdir, err = os.MkdirTemp("", "test-catalog-cache")
+		Expect(err).ToNot(HaveOccurred())
+		common.DefaultCacheDir = cdir
+
+		configFile, err = os.CreateTemp("", "config")
+		Expect(err).To(BeNil())
+		err = copy.Copy(filepath.Join("..", "fakes", "config", "tanzu_config.yaml"), configFile.Name())
+		Expect(err).To(BeNil(), "Error while copying tanzu config file for testing")
+		os.Setenv("TANZU_CONFIG", configFile.Name())
+
+		configFileNG, err = os.CreateTemp("", "config_ng")
+		Expect(err).To(BeNil())
+		os.Setenv("TANZU_CONFIG_NEXT_GEN", configFileNG.Name())
+		err = copy.Copy(filepath.Join("..", "fakes", "config", "tanzu_config_ng.yaml"), configFileNG.Name())
+		Expect(err).To(BeNil(), "Error while coping tanzu config-ng file for testing")
+
+		_, err = fakeInstallPluginVersion("", pluginName, "v0.1.0", types.TargetTMC)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = fakeInstallPluginVersion(tmcContextName, pluginName, "v1.0.0", types.TargetTMC)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = fakeInstallPluginVersion(tmcUseContextName, pluginName, "v2.0.0", types.TargetTMC)
+		Expect(err).ToNot(HaveOccurred())
+	})
+	AfterEach(func() {
+		os.RemoveAll(cdir)
+		os.Unsetenv("TANZU_CONFIG")
+		os.Unsetenv("TANZU_CONFIG_NEXT_GEN")
+		os.RemoveAll(configFile.Name())
+		os.RemoveAll(configFileNG.Name())
+	})
+
+	It("should return the version of the plugin installed for the current context", func() {
+		pi, err := GetInstalledPlugin(pluginName, types.TargetTMC)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pi.Version).To(Equal("v1.0.0"))
+
+		err = configlib.SetCurrentContext(tmcUseContextName)
+		Expect(err).ToNot(HaveOccurred())
+		pi, err = GetInstalledPlugin(pluginName, types.TargetTMC)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pi.Version).To(Equal("v2.0.0"))
+	})
+	It("should return the stand-alone plugin if the plugin is not installed for the current context", func() {
+		err = configlib.RemoveCurrentContext(types.TargetTMC)
+		Expect(err).ToNot(HaveOccurred())
+		pi, err := GetInstalledPlugin(pluginName, types.TargetTMC)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pi.Version).To(Equal("v0.1.0"))
+	})
+	It("should return an error if the plugin is not installed", func() {
+		_, err := GetInstalledPlugin(pluginName, types.TargetK8s)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+func fakeInstallPluginVersion(contextName, pluginName, version string, target types.Target) (*cli.PluginInfo, error) {
+	cc, err := catalog.NewContextCatalog(contextName)
+	if err != nil {
+		return nil, err
+	}
+	pi := &cli.PluginInfo{
+		Name:             pluginName,
+		InstallationPath: "/path/to/plugin/" + pluginName + "/" + version,
+		Version:          version,
+		Target:           target,
+	}
+	if err = cc.Upsert(pi); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
 func fakeInstallPlugin(contextName, pluginName string, target types.Target) (*cli.PluginInfo, error) {
 	cc, err := catalog.NewContextCatalog(contextName)
 	if err != nil {
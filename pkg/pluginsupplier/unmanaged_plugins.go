@@ -0,0 +1,189 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginsupplier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+const (
+	// unmanagedPluginPrefix is the prefix of the executables found on PATH as unmanaged plugins
+	unmanagedPluginPrefix = "tanzu-"
+	// unmanagedPluginInfoTimeout is the maximum duration of the "info" command of an unmanaged plugin
+	unmanagedPluginInfoTimeout = 2 * time.Second
+)
+
+// GetUnmanagedPlugins returns the executables named tanzu-<name> found on PATH,
+// which are run as plugins named <name> for the global target. When several
+// executables have the same name, the first one found on PATH is used.
+// As all the directories of PATH are read, GetUnmanagedPlugin should be used
+// to find the plugin of a command.
+func GetUnmanagedPlugins() []cli.PluginInfo {
+	if !unmanagedPluginsEnabled() {
+		return nil
+	}
+	describe, _ := strconv.ParseBool(os.Getenv(constants.UnmanagedPluginInfo))
+
+	self := executablePath()
+	found := map[string]bool{}
+	var plugins []cli.PluginInfo
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			name, ok := unmanagedPluginName(file.Name())
+			if !ok || found[name] {
+				continue
+			}
+			path := filepath.Join(dir, file.Name())
+			if !isExecutable(path) || resolvePath(path) == self {
+				continue
+			}
+			found[name] = true
+			plugins = append(plugins, *newUnmanagedPlugin(name, path, describe))
+		}
+	}
+	return plugins
+}
+
+// GetUnmanagedPlugin returns the first executable named tanzu-<name> found
+// on PATH for a plugin name, or nil if there is none. Unlike
+// GetUnmanagedPlugins, the directories of PATH are not read.
+func GetUnmanagedPlugin(name string) *cli.PluginInfo {
+	if !unmanagedPluginsEnabled() {
+		return nil
+	}
+	fileName := unmanagedPluginPrefix + name
+	if runtime.GOOS == "windows" {
+		fileName += ".exe"
+	}
+	if _, ok := unmanagedPluginName(fileName); !ok {
+		return nil
+	}
+	describe, _ := strconv.ParseBool(os.Getenv(constants.UnmanagedPluginInfo))
+
+	self := executablePath()
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		path := filepath.Join(dir, fileName)
+		if isExecutable(path) && resolvePath(path) != self {
+			return newUnmanagedPlugin(name, path, describe)
+		}
+	}
+	return nil
+}
+
+func unmanagedPluginsEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(constants.UnmanagedPlugins))
+	return err != nil || enabled
+}
+
+func newUnmanagedPlugin(name, path string, describe bool) *cli.PluginInfo {
+	pi := &cli.PluginInfo{
+		Name:             name,
+		Description:      "Unmanaged plugin " + path,
+		Group:            plugin.ExtraCmdGroup,
+		InstallationPath: path,
+		Target:           configtypes.TargetGlobal,
+		Scope:            common.PluginScopeStandalone,
+		Status:           common.PluginStatusUnmanaged,
+	}
+	if describe {
+		describeUnmanagedPlugin(pi)
+	}
+	return pi
+}
+
+// unmanagedPluginName returns the plugin name of an executable named tanzu-<name>
+func unmanagedPluginName(fileName string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(fileName), ".exe") {
+			return "", false
+		}
+		fileName = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	}
+	if !strings.HasPrefix(fileName, unmanagedPluginPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(fileName, unmanagedPluginPrefix)
+	if name == "" || strings.ContainsAny(name, " \t./\\") {
+		return "", false
+	}
+	return name, true
+}
+
+// describeUnmanagedPlugin completes the information of an unmanaged plugin with the
+// output of its "info" command, if it implements the commands of the plugin runtime
+func describeUnmanagedPlugin(pi *cli.PluginInfo) {
+	ctx, cancel := context.WithTimeout(context.Background(), unmanagedPluginInfoTimeout)
+	defer cancel()
+	b, err := exec.CommandContext(ctx, pi.InstallationPath, "info").Output()
+	if err != nil {
+		log.V(6).Infof("unable to describe the unmanaged plugin %q: %v", pi.InstallationPath, err)
+		return
+	}
+	var info cli.PluginInfo
+	if err := json.Unmarshal(b, &info); err != nil {
+		log.V(6).Infof("unable to describe the unmanaged plugin %q: %v", pi.InstallationPath, err)
+		return
+	}
+	if info.Description != "" {
+		pi.Description = info.Description
+	}
+	if info.Group != "" {
+		pi.Group = info.Group
+	}
+	pi.Version = info.Version
+	pi.BuildSHA = info.BuildSHA
+	pi.Hidden = info.Hidden
+	pi.Aliases = info.Aliases
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0111 != 0
+}
+
+// executablePath returns the resolved path of the CLI executable
+func executablePath() string {
+	path, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	return resolvePath(path)
+}
+
+func resolvePath(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return path
+	}
+	return resolved
+}
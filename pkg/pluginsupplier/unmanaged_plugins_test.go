@@ -0,0 +1,109 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package pluginsupplier
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const unmanagedPluginScript = `#!/bin/sh
+if [ "$1" = "info" ]; then
+  echo '{"name": "ignored", "description": "Hello from the PATH", "version": "v1.0.0", "group": "Build"}'
+fi
+`
+
+var _ = Describe("GetUnmanagedPlugins", func() {
+	var (
+		dir1, dir2 string
+		path       string
+		err        error
+	)
+	writeExecutable := func(dir, name, content string, mode os.FileMode) {
+		Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), mode)).To(Succeed())
+	}
+	BeforeEach(func() {
+		dir1, err = os.MkdirTemp("", "unmanaged-plugins")
+		Expect(err).ToNot(HaveOccurred())
+		dir2, err = os.MkdirTemp("", "unmanaged-plugins")
+		Expect(err).ToNot(HaveOccurred())
+
+		writeExecutable(dir1, "tanzu-hello", unmanagedPluginScript, 0755)
+		writeExecutable(dir2, "tanzu-hello", unmanagedPluginScript, 0755)
+		writeExecutable(dir2, "tanzu-world", "#!/bin/sh\nexit 1\n", 0755)
+		writeExecutable(dir2, "tanzu-notexecutable", "", 0644)
+		writeExecutable(dir2, "tanzu-", "", 0755)
+		writeExecutable(dir2, "other", "", 0755)
+		Expect(os.Mkdir(filepath.Join(dir2, "tanzu-dir"), 0755)).To(Succeed())
+
+		path = os.Getenv("PATH")
+		os.Setenv("PATH", dir1+string(os.PathListSeparator)+dir2)
+	})
+	AfterEach(func() {
+		os.Setenv("PATH", path)
+		os.Unsetenv(constants.UnmanagedPlugins)
+		os.Unsetenv(constants.UnmanagedPluginInfo)
+		os.RemoveAll(dir1)
+		os.RemoveAll(dir2)
+	})
+
+	Context("when the executables are not described", func() {
+		It("should return the first tanzu-<name> executable of each name found on PATH", func() {
+			plugins := GetUnmanagedPlugins()
+			Expect(len(plugins)).To(Equal(2))
+			Expect(plugins[0].Name).To(Equal("hello"))
+			Expect(plugins[0].InstallationPath).To(Equal(filepath.Join(dir1, "tanzu-hello")))
+			Expect(plugins[0].Target).To(Equal(types.TargetGlobal))
+			Expect(plugins[0].Status).To(Equal(common.PluginStatusUnmanaged))
+			Expect(plugins[0].Scope).To(Equal(common.PluginScopeStandalone))
+			Expect(plugins[0].Version).To(BeEmpty())
+			Expect(plugins[1].Name).To(Equal("world"))
+		})
+	})
+	Context("when the executables are described", func() {
+		It("should use the output of the info command of the plugins implementing it", func() {
+			os.Setenv(constants.UnmanagedPluginInfo, "true")
+			plugins := GetUnmanagedPlugins()
+			Expect(len(plugins)).To(Equal(2))
+			Expect(plugins[0].Name).To(Equal("hello"))
+			Expect(plugins[0].Description).To(Equal("Hello from the PATH"))
+			Expect(plugins[0].Version).To(Equal("v1.0.0"))
+			Expect(plugins[0].Group).To(Equal(plugin.BuildCmdGroup))
+			Expect(plugins[1].Version).To(BeEmpty())
+			Expect(plugins[1].Group).To(Equal(plugin.ExtraCmdGroup))
+		})
+	})
+	Context("when the unmanaged plugins are disabled", func() {
+		It("should return no plugin", func() {
+			os.Setenv(constants.UnmanagedPlugins, "false")
+			Expect(GetUnmanagedPlugins()).To(BeEmpty())
+			Expect(GetUnmanagedPlugin("hello")).To(BeNil())
+		})
+	})
+	Context("when an executable is looked up by name", func() {
+		It("should return the first tanzu-<name> executable found on PATH", func() {
+			pi := GetUnmanagedPlugin("hello")
+			Expect(pi).ToNot(BeNil())
+			Expect(pi.Name).To(Equal("hello"))
+			Expect(pi.InstallationPath).To(Equal(filepath.Join(dir1, "tanzu-hello")))
+			Expect(pi.Status).To(Equal(common.PluginStatusUnmanaged))
+			Expect(GetUnmanagedPlugin("world")).ToNot(BeNil())
+		})
+		It("should not return the files which are not valid plugins", func() {
+			Expect(GetUnmanagedPlugin("notexecutable")).To(BeNil())
+			Expect(GetUnmanagedPlugin("dir")).To(BeNil())
+			Expect(GetUnmanagedPlugin("")).To(BeNil())
+			Expect(GetUnmanagedPlugin("../tanzu-hello")).To(BeNil())
+			Expect(GetUnmanagedPlugin("unknown")).To(BeNil())
+		})
+	})
+})
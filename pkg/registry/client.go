@@ -182,18 +182,43 @@ func (r *registry) downloadBundleOrImage(imageName, outputDir string, isBundle b
 	} else {
 		pullOptions.ImageFlags = cmd.ImageFlags{Image: imageName}
 	}
-	if r.opts != nil {
-		pullOptions.RegistryFlags = cmd.RegistryFlags{
-			CACertPaths: r.opts.CACertPaths,
-			VerifyCerts: r.opts.VerifyCerts,
-			Insecure:    r.opts.Insecure,
-			Anon:        r.opts.Anon,
-		}
-	}
+	pullOptions.RegistryFlags = r.registryFlags()
 
 	return pullOptions.Run()
 }
 
+// UploadImage uploads the content of a directory as an OCI image
+// similarly to the `imgpkg push -i` command
+func (r *registry) UploadImage(imageWithTag, sourceDir string) error {
+	// Creating a dummy writer to capture the logs
+	// currently this logs are not displayed or used directly
+	var outputBuf, errorBuf bytes.Buffer
+	writerUI := ui.NewWriterUI(&outputBuf, &errorBuf, nil)
+
+	pushOptions := cmd.NewPushOptions(writerUI)
+	pushOptions.ImageFlags = cmd.ImageFlags{Image: imageWithTag}
+	pushOptions.FileFlags = cmd.FileFlags{Files: []string{sourceDir}}
+	pushOptions.RegistryFlags = r.registryFlags()
+	// Pushing to a registry requires credentials in most cases,
+	// so let imgpkg use the local docker keychain
+	pushOptions.RegistryFlags.Anon = false
+
+	return pushOptions.Run()
+}
+
+// registryFlags converts the registry options to the imgpkg flags
+func (r *registry) registryFlags() cmd.RegistryFlags {
+	if r.opts == nil {
+		return cmd.RegistryFlags{}
+	}
+	return cmd.RegistryFlags{
+		CACertPaths: r.opts.CACertPaths,
+		VerifyCerts: r.opts.VerifyCerts,
+		Insecure:    r.opts.Insecure,
+		Anon:        r.opts.Anon,
+	}
+}
+
 // GetImageDigest gets the digest of an OCI image similarly to the `imgpkg tag resolve -i` command
 func (r *registry) GetImageDigest(imageWithTag string) (string, string, error) {
 	ref, err := regname.ParseReference(imageWithTag, regname.WeakValidation)
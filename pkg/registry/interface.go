@@ -21,4 +21,7 @@ type Registry interface {
 	DownloadImage(imageName, outputDir string) error
 	// GetImageDigest gets the digest of an OCI image similar to the `imgpkg tag resolve -i` command
 	GetImageDigest(imageWithTag string) (string, string, error)
+	// UploadImage uploads the content of a directory as an OCI image similarly
+	// to the `imgpkg push -i` command
+	UploadImage(imageWithTag, sourceDir string) error
 }